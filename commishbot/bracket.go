@@ -0,0 +1,342 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+)
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type BracketType string
+
+const (
+   WinnersBracket BracketType = "winners_bracket"
+   LosersBracket BracketType = "losers_bracket"
+)
+
+//--------------------------------------------------------------------------------------------------
+// Teams in later rounds come from the winner or the loser of an earlier match.
+//--------------------------------------------------------------------------------------------------
+type BracketSource struct {
+   Winner_of int `json:"w"`
+   Loser_of int `json:"l"`
+}
+
+//--------------------------------------------------------------------------------------------------
+// Teams, winners and losers are roster Ids, and are zero until they are decided. Placement is the
+// place the winner of the match finishes in, and is zero for matches that lead to a later round.
+//--------------------------------------------------------------------------------------------------
+type BracketMatch struct {
+   Round int `json:"r"`
+   Match_id int `json:"m"`
+   Team1 int `json:"t1"`
+   Team2 int `json:"t2"`
+   Team1_from *BracketSource `json:"t1_from"`
+   Team2_from *BracketSource `json:"t2_from"`
+   Winner int `json:"w"`
+   Loser int `json:"l"`
+   Placement int `json:"p"`
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type Bracket []BracketMatch
+
+//--------------------------------------------------------------------------------------------------
+// Points are the team's score in the week the match is played, once that week's matchups are loaded.
+//--------------------------------------------------------------------------------------------------
+type BracketTeam struct {
+   Roster_id int
+   Owner string
+   From string
+   Points float64
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type BracketMatchReport struct {
+   Match_id int
+   Name string
+   Team1 BracketTeam
+   Team2 BracketTeam
+   Winner string
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type BracketRoundReport struct {
+   Round int
+   Week int
+   Matches []BracketMatchReport
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type BracketReport struct {
+   Name string
+   Rounds []BracketRoundReport
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (bracketType BracketType) Describe() string {
+
+   switch bracketType {
+   case WinnersBracket:
+      return "Winners Bracket"
+   case LosersBracket:
+      return "Losers Bracket"
+   }
+
+   return string(bracketType)
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (client *SleeperClient) GetBracketData(pContext context.Context, pLeagueId string, pBracketType BracketType) (string, error) {
+   return client.getHttpResponse(pContext, client.mBaseUrl + "/league/" + pLeagueId + "/" + string(pBracketType), defaultCacheTtl)
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (client *SleeperClient) GetBracket(pContext context.Context, pLeagueId string, pBracketType BracketType) (Bracket, error) {
+
+   bracketData, err := client.GetBracketData(pContext, pLeagueId, pBracketType)
+
+   if err != nil {
+      return nil, err
+   }
+
+   var bracket Bracket
+   err = unmarshalSleeperData(bracketData, "league " + pLeagueId + " " + pBracketType.Describe(), &bracket)
+
+   if err != nil {
+      return nil, err
+   }
+
+   return bracket, nil
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (bracket Bracket) GetNumRounds() int {
+   numRounds := 0
+
+   for _, match := range bracket {
+      numRounds = max(numRounds, match.Round)
+   }
+
+   return numRounds
+}
+
+//--------------------------------------------------------------------------------------------------
+// A winners bracket match eliminates its loser from the title when neither team reached it by losing
+// an earlier match. Placement matches between teams that were already eliminated do not count.
+//--------------------------------------------------------------------------------------------------
+func (match BracketMatch) IsElimination() bool {
+
+   for _, source := range []*BracketSource{match.Team1_from, match.Team2_from} {
+      if source != nil && source.Loser_of != 0 {
+         return false
+      }
+   }
+
+   return true
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (bracket Bracket) GetEliminatedRosterIds(pRound int) []int {
+   var eliminatedRosterIds []int
+
+   for _, match := range bracket {
+      if match.Round == pRound && match.Loser != 0 && match.IsElimination() {
+         eliminatedRosterIds = append(eliminatedRosterIds, match.Loser)
+      }
+   }
+
+   return eliminatedRosterIds
+}
+
+//--------------------------------------------------------------------------------------------------
+// GetFinalPlaces maps roster Ids to the place they finished in, from the placement matches decided so
+// far. The winner of a placement match takes its place and the loser the one after it.
+//--------------------------------------------------------------------------------------------------
+func (bracket Bracket) GetFinalPlaces() map[int]int {
+   finalPlaces := make(map[int]int)
+
+   for _, match := range bracket {
+      if match.Placement != 0 && match.Winner != 0 && match.Loser != 0 {
+         finalPlaces[match.Winner] = match.Placement
+         finalPlaces[match.Loser] = match.Placement + 1
+      }
+   }
+
+   return finalPlaces
+}
+
+//--------------------------------------------------------------------------------------------------
+// GetBracketReport resolves a loaded bracket's roster Ids to owners and its rounds to weeks.
+//--------------------------------------------------------------------------------------------------
+func GetBracketReport(pSeasonData SeasonData, pBracketType BracketType) (BracketReport, error) {
+
+   bracket, err := pSeasonData.GetBracket(pBracketType)
+
+   if err != nil {
+      return BracketReport{}, err
+   }
+
+   league := pSeasonData.mLeagueInfo.mLeague
+
+   var bracketReport BracketReport
+   bracketReport.Name = pBracketType.Describe()
+
+   for round := 1 ; round <= bracket.GetNumRounds() ; round++ {
+
+      roundReport := BracketRoundReport{Round: round, Week: league.GetPlayoffRoundWeek(round)}
+      matchups := pSeasonData.mMatchups[roundReport.Week]
+
+      for _, match := range bracket {
+         if match.Round != round {
+            continue
+         }
+
+         var matchReport BracketMatchReport
+         matchReport.Match_id = match.Match_id
+         matchReport.Name = getBracketMatchName(pBracketType, match)
+         matchReport.Team1 = makeBracketTeam(pSeasonData.mLeagueInfo, matchups, match.Team1, match.Team1_from)
+         matchReport.Team2 = makeBracketTeam(pSeasonData.mLeagueInfo, matchups, match.Team2, match.Team2_from)
+
+         if match.Winner != 0 {
+            matchReport.Winner = pSeasonData.mLeagueInfo.GetOwnerName(match.Winner)
+         }
+
+         roundReport.Matches = append(roundReport.Matches, matchReport)
+      }
+
+      bracketReport.Rounds = append(bracketReport.Rounds, roundReport)
+   }
+
+   return bracketReport, nil
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func makeBracketTeam(pLeagueInfo LeagueInfo, pMatchups []Matchup, pRosterId int, pSource *BracketSource) BracketTeam {
+
+   var bracketTeam BracketTeam
+   bracketTeam.Roster_id = pRosterId
+
+   switch {
+   case pSource != nil && pSource.Winner_of != 0:
+      bracketTeam.From = "Winner of Match " + strconv.Itoa(pSource.Winner_of)
+   case pSource != nil && pSource.Loser_of != 0:
+      bracketTeam.From = "Loser of Match " + strconv.Itoa(pSource.Loser_of)
+   }
+
+   if pRosterId == 0 {
+      return bracketTeam
+   }
+
+   bracketTeam.Owner = pLeagueInfo.GetOwnerName(pRosterId)
+
+   if matchup, err := GetMatchupRoster(pMatchups, pRosterId) ; err == nil {
+      bracketTeam.Points = matchup.Points
+   }
+
+   return bracketTeam
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func getBracketMatchName(pBracketType BracketType, pMatch BracketMatch) string {
+
+   switch {
+   case pMatch.Placement == 0:
+      return "Match " + strconv.Itoa(pMatch.Match_id)
+   case pMatch.Placement == 1 && pBracketType == WinnersBracket:
+      return "Championship"
+   case pMatch.Placement == 1:
+      return "Toilet Bowl"
+   }
+
+   return getOrdinal(pMatch.Placement) + " Place"
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func getOrdinal(pNumber int) string {
+
+   suffix := "th"
+
+   switch {
+   case pNumber % 100 >= 11 && pNumber % 100 <= 13:
+   case pNumber % 10 == 1:
+      suffix = "st"
+   case pNumber % 10 == 2:
+      suffix = "nd"
+   case pNumber % 10 == 3:
+      suffix = "rd"
+   }
+
+   return strconv.Itoa(pNumber) + suffix
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (bracketTeam BracketTeam) Describe() string {
+
+   if bracketTeam.Roster_id == 0 {
+      if bracketTeam.From != "" {
+         return bracketTeam.From
+      }
+
+      return "TBD"
+   }
+
+   if bracketTeam.Points != 0.0 {
+      return fmt.Sprintf("%s (%.2f)", bracketTeam.Owner, bracketTeam.Points)
+   }
+
+   return bracketTeam.Owner
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (bracketReport BracketReport) Print() {
+
+   log.Printf("%s", bracketReport.Name)
+
+   for _, round := range bracketReport.Rounds {
+
+      log.Printf("   Round %d (Week %d)", round.Round, round.Week)
+
+      for _, match := range round.Matches {
+
+         line := fmt.Sprintf("      %s: %s vs %s", match.Name, match.Team1.Describe(), match.Team2.Describe())
+
+         if match.Winner != "" {
+            line += ", Winner: " + match.Winner
+         }
+
+         log.Print(line)
+      }
+   }
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+//--------------------------------------------------------------------------------------------------
+// The fixture's playoffs start in week 15 with six teams, the Jaguars and Icemen on byes, and end
+// with the Aces beating the Dragons for the title in week 17.
+//--------------------------------------------------------------------------------------------------
+func TestBrackets(pTest *testing.T) {
+
+   earlyExit, _ := GetPrize("Early Exit")
+   prizeSchedule := []ScheduledPrize{{14, earlyExit}, {15, earlyExit}, {16, earlyExit}, {17, earlyExit}}
+   _, seasonData := newTestSeason(pTest, prizeSchedule)
+
+   bracketReport, err := GetBracketReport(seasonData, WinnersBracket)
+
+   if err != nil {
+      pTest.Fatalf("GetBracketReport: %s", err.Error())
+   }
+
+   if len(bracketReport.Rounds) != 3 || bracketReport.Rounds[0].Week != 15 || bracketReport.Rounds[2].Week != 17 {
+      pTest.Fatalf("Rounds %+v, expected 3 rounds in weeks 15-17", bracketReport.Rounds)
+   }
+
+   championship := bracketReport.Rounds[2].Matches[0]
+
+   if championship.Name != "Championship" || championship.Winner != "Aces" || championship.Team1.Describe() != "Aces (143.00)" || championship.Team1.From != "Winner of Match 3" {
+      pTest.Errorf("Championship %+v, expected the Aces (143.00) to win after winning match 3", championship)
+   }
+
+   byeMatch := bracketReport.Rounds[1].Matches[0]
+
+   if byeMatch.Team1.Owner != "Jaguars" || byeMatch.Team1.From != "" || byeMatch.Team2.Owner != "Aces" {
+      pTest.Errorf("Round 2 match %+v, expected the Jaguars off a bye against the Aces", byeMatch)
+   }
+
+   losersReport, err := GetBracketReport(seasonData, LosersBracket)
+
+   if err != nil || losersReport.Rounds[1].Matches[0].Name != "Toilet Bowl" {
+      pTest.Errorf("Losers bracket %+v (%v), expected the toilet bowl in round 2", losersReport, err)
+   }
+
+   // The brackets command's JSON output decodes back to the same reports
+   env, output := newTestCommandEnv(pTest, FormatJson)
+   err = runTestCommand(pTest, env, "brackets")
+
+   if err != nil {
+      pTest.Fatalf("brackets: %s", err.Error())
+   }
+
+   var decodedReports []BracketReport
+   err = json.Unmarshal(output.Bytes(), &decodedReports)
+
+   if expectedReports := []BracketReport{bracketReport, losersReport} ; err != nil || !reflect.DeepEqual(decodedReports, expectedReports) {
+      pTest.Errorf("JSON output gave %+v (%v), expected %+v", decodedReports, err, expectedReports)
+   }
+
+   // The losers of the 3rd and 5th place games were already out of the running for the title
+   expectedWinners := map[int]string{14: "", 15: "Giants", 16: "Icemen", 17: "Dragons"}
+
+   for week, expectedWinner := range expectedWinners {
+
+      summary := GetWeekSummary(earlyExit, seasonData, week)
+      winner := ""
+
+      if summary.HasWinner() {
+         winner = summary.PrizeEntries[0].Owner
+      }
+
+      if summary.Err != nil || winner != expectedWinner {
+         pTest.Errorf("Week %d: Winner %q (%v), expected %q", week, winner, summary.Err, expectedWinner)
+      }
+   }
+}
@@ -0,0 +1,1015 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"time"
+)
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type OutputFormat string
+
+const (
+   FormatText OutputFormat = "text"
+   FormatJson OutputFormat = "json"
+)
+
+//--------------------------------------------------------------------------------------------------
+// Commands that need fresh Sleeper data revalidate every cached response. Per league commands run once
+// for each selected league.
+//--------------------------------------------------------------------------------------------------
+type Command struct {
+   Name string
+   Usage string
+   Description string
+   NeedsFreshData bool
+   PerLeague bool
+   Run func(pEnv *CommandEnv, pArgs []string) error
+}
+
+//--------------------------------------------------------------------------------------------------
+// CommandEnv holds what every command shares. Text output is logged, and JSON output is written to
+// mOutput. mLeague is selected from the user's leagues by mLeagueSelectors when it is not set. When
+// several leagues are run together, mSeparateLeagues labels each league's JSON output.
+//--------------------------------------------------------------------------------------------------
+type CommandEnv struct {
+   mContext context.Context
+   mConfig Config
+   mClient *SleeperClient
+   mFormat OutputFormat
+   mOutput io.Writer
+   mLeagueSelectors []string
+   mLeague *League
+   mSeparateLeagues bool
+   mNflState *NflState
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type LeagueOutput struct {
+   League_id string
+   Name string
+   Output any
+}
+
+//--------------------------------------------------------------------------------------------------
+// The report is everything a run printed before there were commands.
+//--------------------------------------------------------------------------------------------------
+type Report struct {
+   Summaries []WeekSummary
+   Standings *Standings `json:",omitempty"`
+   PowerRankings *PowerRankings `json:",omitempty"`
+   SeasonAwards *SeasonAwards `json:",omitempty"`
+   PlayoffOdds *PlayoffOdds `json:",omitempty"`
+   Brackets []BracketReport `json:",omitempty"`
+}
+
+var commands []Command
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func init() {
+   commands = []Command{
+      {"report", "report", "Print the prize summaries, ledger, standings and the rest of the season report (the default)", false, true, runReportCommand},
+      {"summary", "summary [--week N | --all]", "Print the prize summary of a week, by default the last completed one", false, true, runSummaryCommand},
+      {"standings", "standings", "Print the standings after the last completed week", false, true, runStandingsCommand},
+      {"power-rankings", "power-rankings", "Print the power rankings after the last completed week", false, true, runPowerRankingsCommand},
+      {"playoff-odds", "playoff-odds", "Simulate the rest of the regular season and print each team's playoff odds", false, true, runPlayoffOddsCommand},
+      {"brackets", "brackets", "Print the winners and losers playoff brackets", false, true, runBracketsCommand},
+      {"awards", "awards", "Print the season awards for the completed regular season weeks", false, true, runAwardsCommand},
+      {"ledger", "ledger [--mark-sent ID]", "Post decided prizes to the prize ledger and print it", false, true, runLedgerCommand},
+      {"recheck", "recheck", "Recompute the finalized weeks from fresh Sleeper data and report what changed", true, true, runRecheckCommand},
+      {"leagues", "leagues", "List the user's leagues for the year", false, false, runLeaguesCommand},
+      {"players", "players search <name>", "Search the NFL players by name", false, false, runPlayersCommand},
+      {"prizes", "prizes", "List the prizes that can be scheduled", false, false, runPrizesCommand},
+   }
+}
+
+//--------------------------------------------------------------------------------------------------
+// Stat corrections only show up in freshly fetched data, so commands that need it bypass both the
+// response cache and the stats snapshots.
+//--------------------------------------------------------------------------------------------------
+func NewCommandSleeperClient(pConfig Config, pCommand Command) *SleeperClient {
+
+   if pCommand.NeedsFreshData {
+      pConfig.CacheMode = CacheModeRefresh
+   }
+
+   return NewConfigSleeperClient(pConfig)
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func GetCommand(pName string) (Command, bool) {
+
+   for _, command := range commands {
+      if command.Name == pName {
+         return command, true
+      }
+   }
+
+   return Command{}, false
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func ParseOutputFormat(pName string) (OutputFormat, error) {
+
+   switch OutputFormat(strings.ToLower(pName)) {
+   case FormatText:
+      return FormatText, nil
+   case FormatJson:
+      return FormatJson, nil
+   }
+
+   return "", fmt.Errorf("unknown output format %q (expected %s or %s)", pName, FormatText, FormatJson)
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func printCommandsHelp(pWriter io.Writer) {
+   fmt.Fprintf(pWriter, "Commands:\n")
+
+   for _, command := range commands {
+      fmt.Fprintf(pWriter, "  %-26s %s\n", command.Usage, command.Description)
+   }
+}
+
+//--------------------------------------------------------------------------------------------------
+// The help for each prize is its criteria.
+//--------------------------------------------------------------------------------------------------
+func printPrizesHelp(pWriter io.Writer) {
+   fmt.Fprintf(pWriter, "Prizes:\n")
+
+   for _, prize := range GetPrizes() {
+      fmt.Fprintf(pWriter, "  %-18s %s\n", prize.Name(), prize.Criteria())
+   }
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func newCommandFlagSet(pCommand Command, pPrintPrizes bool) *flag.FlagSet {
+
+   flagSet := flag.NewFlagSet(pCommand.Name, flag.ContinueOnError)
+
+   flagSet.Usage = func() {
+      fmt.Fprintf(flagSet.Output(), "Usage: commishbot [global flags] %s\n\n%s\n", pCommand.Usage, pCommand.Description)
+      flagSet.PrintDefaults()
+
+      if pPrintPrizes {
+         fmt.Fprintln(flagSet.Output())
+         printPrizesHelp(flagSet.Output())
+      }
+   }
+
+   return flagSet
+}
+
+//--------------------------------------------------------------------------------------------------
+// parseCommandFlags parses the flags of a command that takes no arguments of its own.
+//--------------------------------------------------------------------------------------------------
+func parseCommandFlags(pFlagSet *flag.FlagSet, pArgs []string) error {
+
+   err := pFlagSet.Parse(pArgs)
+
+   if err != nil {
+      return err
+   }
+
+   if pFlagSet.NArg() > 0 {
+      pFlagSet.Usage()
+      return fmt.Errorf("%s: unexpected arguments %v", pFlagSet.Name(), pFlagSet.Args())
+   }
+
+   return nil
+}
+
+//--------------------------------------------------------------------------------------------------
+// output writes pValue as JSON, or calls pPrintText for text output.
+//--------------------------------------------------------------------------------------------------
+func (env *CommandEnv) output(pValue any, pPrintText func()) error {
+
+   if env.mFormat != FormatJson {
+      pPrintText()
+      return nil
+   }
+
+   if env.mSeparateLeagues && env.mLeague != nil {
+      pValue = LeagueOutput{env.mLeague.League_id, env.mLeague.Name, pValue}
+   }
+
+   encoder := json.NewEncoder(env.mOutput)
+   encoder.SetIndent("", "   ")
+
+   return encoder.Encode(pValue)
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (env *CommandEnv) getUserLeagues() ([]League, error) {
+
+   user, err := env.mClient.GetUser(env.mContext, env.mConfig.Username)
+
+   if err != nil {
+      return nil, err
+   }
+
+   return env.mClient.GetUserLeagues(env.mContext, user.User_id, env.mConfig.Year)
+}
+
+//--------------------------------------------------------------------------------------------------
+// getSelectedLeagues lists the user's leagues when it is not clear which of them to use.
+//--------------------------------------------------------------------------------------------------
+func (env *CommandEnv) getSelectedLeagues() ([]League, error) {
+
+   userLeagues, err := env.getUserLeagues()
+
+   if err != nil {
+      return nil, err
+   }
+
+   selectedLeagues, err := SelectLeagues(userLeagues, env.mLeagueSelectors)
+
+   if err != nil && len(userLeagues) > 0 {
+      printLeagues(env.mConfig.Username, env.mConfig.Year, userLeagues)
+   }
+
+   return selectedLeagues, err
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (env *CommandEnv) getLeagueId() (string, error) {
+
+   if env.mLeague != nil {
+      return env.mLeague.League_id, nil
+   }
+
+   selectedLeagues, err := env.getSelectedLeagues()
+
+   if err != nil {
+      return "", err
+   }
+
+   if len(selectedLeagues) > 1 {
+      return "", fmt.Errorf("getLeagueId: %d leagues are selected, expected one", len(selectedLeagues))
+   }
+
+   env.mLeague = &selectedLeagues[0]
+
+   return env.mLeague.League_id, nil
+}
+
+//--------------------------------------------------------------------------------------------------
+// forLeague returns a copy of the env for running a command on pLeague. The leagues of a run share the
+// config, so each keeps its own ledger file when pSeparateLeagues is set.
+//--------------------------------------------------------------------------------------------------
+func (env *CommandEnv) forLeague(pLeague League, pSeparateLeagues bool) *CommandEnv {
+
+   leagueEnv := *env
+   leagueEnv.mLeague = &pLeague
+   leagueEnv.mSeparateLeagues = pSeparateLeagues
+
+   if pSeparateLeagues && env.mConfig.Ledger != nil {
+      ledgerConfig := *env.mConfig.Ledger
+      ledgerConfig.File = getLeagueFilePath(ledgerConfig.File, pLeague.League_id)
+      leagueEnv.mConfig.Ledger = &ledgerConfig
+   }
+
+   return &leagueEnv
+}
+
+//--------------------------------------------------------------------------------------------------
+// getCompletedWeeks returns how many weeks of the whole season, and of the regular season, are over.
+// Sleeper reports the fantasy playoffs as part of the NFL regular season.
+//--------------------------------------------------------------------------------------------------
+func (env *CommandEnv) getCompletedWeeks() (int, int, error) {
+
+   nflState, err := env.getNflState()
+
+   if err != nil {
+      return 0, 0, err
+   }
+
+   completedSeasonWeeks := nflState.GetCompletedWeeks(env.mConfig.Year, env.mConfig.GetSeasonWeeks())
+
+   return completedSeasonWeeks, min(completedSeasonWeeks, env.mConfig.RegularSeasonWeeks), nil
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (env *CommandEnv) getNflState() (NflState, error) {
+
+   if env.mNflState == nil {
+      nflState, err := env.mClient.GetNflState(env.mContext)
+
+      if err != nil {
+         return NflState{}, err
+      }
+
+      env.mNflState = &nflState
+   }
+
+   return *env.mNflState, nil
+}
+
+//--------------------------------------------------------------------------------------------------
+// loadSeasonData loads the data of pPrizeSchedule's prizes together with the matchups of every week
+// of the season, which the standings and the brackets are built from.
+//--------------------------------------------------------------------------------------------------
+func (env *CommandEnv) loadSeasonData(pPrizeSchedule []ScheduledPrize, pRequiredData PrizeData) (SeasonData, error) {
+
+   leagueId, err := env.getLeagueId()
+
+   if err != nil {
+      return SeasonData{}, err
+   }
+
+   nflState, err := env.getNflState()
+
+   if err != nil {
+      return SeasonData{}, err
+   }
+
+   seasonDataOptions := MakeSeasonDataOptions(pPrizeSchedule)
+   seasonDataOptions.GameTimes = env.mConfig.mGameTimes
+   seasonDataOptions.RosterWeek = nflState.GetCurrentWeek(env.mConfig.Year)
+
+   if pRequiredData & PrizeDataPlayers != 0 {
+      seasonDataOptions.LoadPlayers = true
+   }
+
+   if pRequiredData & PrizeDataBrackets != 0 {
+      seasonDataOptions.LoadBrackets = true
+   }
+
+   for week := 1 ; week <= env.mConfig.GetSeasonWeeks() ; week++ {
+      seasonDataOptions.MatchupWeeks = append(seasonDataOptions.MatchupWeeks, week)
+   }
+
+   return LoadSeasonData(env.mContext, env.mClient, leagueId, env.mConfig.Year, seasonDataOptions)
+}
+
+//--------------------------------------------------------------------------------------------------
+// getWeekSummaries reports locked weeks from the results store and computes the open ones, finalizing
+// those that are over. Only the open weeks' prize data is loaded. pRequiredData is loaded on top for
+// the caller.
+//--------------------------------------------------------------------------------------------------
+func (env *CommandEnv) getWeekSummaries(pRequiredData PrizeData) ([]WeekSummary, SeasonData, error) {
+
+   leagueId, err := env.getLeagueId()
+
+   if err != nil {
+      return nil, SeasonData{}, err
+   }
+
+   completedSeasonWeeks, _, err := env.getCompletedWeeks()
+
+   if err != nil {
+      return nil, SeasonData{}, err
+   }
+
+   resultsStore := NewResultsStore(env.mConfig.ResultsDir, leagueId)
+   lockedResults, err := resultsStore.GetLockedResults(env.mConfig.mPrizeSchedule)
+
+   if err != nil {
+      log.Print(err)
+   }
+
+   seasonData, err := env.loadSeasonData(GetOpenPrizes(env.mConfig.mPrizeSchedule, lockedResults), pRequiredData)
+
+   if err != nil {
+      return nil, SeasonData{}, err
+   }
+
+   var summaries []WeekSummary
+
+   for _, scheduledPrize := range env.mConfig.mPrizeSchedule {
+
+      if lockedResult, isLocked := lockedResults[scheduledPrize.mWeek] ; isLocked {
+         lockedResult.Summary.Finalized = true
+         summaries = append(summaries, lockedResult.Summary)
+         continue
+      }
+
+      summary := GetWeekSummary(scheduledPrize.mPrize, seasonData, scheduledPrize.mWeek)
+
+      if summary.Week <= completedSeasonWeeks && summary.Err == nil {
+         _, err = resultsStore.FinalizeWeek(seasonData, scheduledPrize.mPrize, summary)
+
+         if err != nil {
+            log.Print(err)
+         } else {
+            summary.Finalized = true
+         }
+      }
+
+      summaries = append(summaries, summary)
+   }
+
+   ApplyRolloverPolicy(summaries, env.mConfig.mRolloverPolicy)
+
+   return summaries, seasonData, nil
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func printSummaries(pSummaries []WeekSummary) {
+   for _, summary := range pSummaries {
+      summary.Print()
+   }
+}
+
+//--------------------------------------------------------------------------------------------------
+// runReportCommand prints the prize summaries and posts them to the ledger, then prints the standings
+// and power rankings, the season awards once the regular season is over, the playoff odds until then
+// and the playoff brackets once they are seeded.
+//--------------------------------------------------------------------------------------------------
+func runReportCommand(pEnv *CommandEnv, pArgs []string) error {
+
+   command, _ := GetCommand("report")
+   err := parseCommandFlags(newCommandFlagSet(command, true), pArgs)
+
+   if err != nil {
+      return err
+   }
+
+   _, completedWeeks, err := pEnv.getCompletedWeeks()
+
+   if err != nil {
+      return err
+   }
+
+   requiredData := PrizeDataBrackets
+
+   if completedWeeks == pEnv.mConfig.RegularSeasonWeeks {
+      requiredData |= GetSeasonAwardsRequiredData()
+   }
+
+   summaries, seasonData, err := pEnv.getWeekSummaries(requiredData)
+
+   if err != nil {
+      return err
+   }
+
+   var report Report
+   report.Summaries = summaries
+
+   if pEnv.mConfig.Ledger != nil {
+      _, err = updateLedger(pEnv.mConfig, seasonData, summaries, "")
+
+      if err != nil {
+         log.Print(err)
+      }
+   }
+
+   if standings, err := GetStandings(seasonData, completedWeeks) ; err != nil {
+      log.Print(err)
+   } else {
+      report.Standings = &standings
+   }
+
+   if completedWeeks > 0 {
+      if powerRankings, err := GetPowerRankings(seasonData, completedWeeks, pEnv.mConfig.RegularSeasonWeeks, *pEnv.mConfig.PowerRankingWeights) ; err != nil {
+         log.Print(err)
+      } else {
+         report.PowerRankings = &powerRankings
+      }
+   }
+
+   if completedWeeks == pEnv.mConfig.RegularSeasonWeeks {
+      seasonAwards := GetSeasonAwards(seasonData, completedWeeks)
+      report.SeasonAwards = &seasonAwards
+   } else if playoffOdds, err := getPlayoffOdds(pEnv, seasonData, completedWeeks) ; err != nil {
+      log.Print(err)
+   } else {
+      report.PlayoffOdds = &playoffOdds
+   }
+
+   report.Brackets = getBracketReports(seasonData)
+
+   return pEnv.output(report, func() {
+      printSummaries(report.Summaries)
+
+      if report.Standings != nil {
+         report.Standings.Print()
+      }
+
+      if report.PowerRankings != nil {
+         report.PowerRankings.Print()
+      }
+
+      if report.SeasonAwards != nil {
+         report.SeasonAwards.Print()
+      }
+
+      if report.PlayoffOdds != nil {
+         report.PlayoffOdds.Print()
+      }
+
+      for _, bracketReport := range report.Brackets {
+         bracketReport.Print()
+      }
+   })
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func runSummaryCommand(pEnv *CommandEnv, pArgs []string) error {
+
+   command, _ := GetCommand("summary")
+   flagSet := newCommandFlagSet(command, true)
+   week := flagSet.Int("week", 0, "Print the summary of this week")
+   all := flagSet.Bool("all", false, "Print the summary of every scheduled week")
+
+   err := parseCommandFlags(flagSet, pArgs)
+
+   if err != nil {
+      return err
+   }
+
+   if *week != 0 && *all {
+      return errors.New("summary: --week and --all cannot be used together")
+   }
+
+   if !*all && *week == 0 {
+      *week, _, err = pEnv.getCompletedWeeks()
+
+      if err != nil {
+         return err
+      }
+
+      if *week == 0 {
+         return errors.New("summary: No weeks have been completed yet, choose one with --week or use --all")
+      }
+   }
+
+   summaries, _, err := pEnv.getWeekSummaries(0)
+
+   if err != nil {
+      return err
+   }
+
+   var selectedSummaries []WeekSummary
+
+   for _, summary := range summaries {
+      if *all || summary.Week == *week {
+         selectedSummaries = append(selectedSummaries, summary)
+      }
+   }
+
+   if len(selectedSummaries) == 0 && !*all {
+      return fmt.Errorf("summary: Week %d has no scheduled prize: %w", *week, ErrNotFound)
+   }
+
+   return pEnv.output(selectedSummaries, func() {
+      printSummaries(selectedSummaries)
+   })
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func runStandingsCommand(pEnv *CommandEnv, pArgs []string) error {
+
+   command, _ := GetCommand("standings")
+   err := parseCommandFlags(newCommandFlagSet(command, false), pArgs)
+
+   if err != nil {
+      return err
+   }
+
+   _, completedWeeks, err := pEnv.getCompletedWeeks()
+
+   if err != nil {
+      return err
+   }
+
+   seasonData, err := pEnv.loadSeasonData(nil, 0)
+
+   if err != nil {
+      return err
+   }
+
+   standings, err := GetStandings(seasonData, completedWeeks)
+
+   if err != nil {
+      return err
+   }
+
+   return pEnv.output(standings, standings.Print)
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func runPowerRankingsCommand(pEnv *CommandEnv, pArgs []string) error {
+
+   command, _ := GetCommand("power-rankings")
+   err := parseCommandFlags(newCommandFlagSet(command, false), pArgs)
+
+   if err != nil {
+      return err
+   }
+
+   _, completedWeeks, err := pEnv.getCompletedWeeks()
+
+   if err != nil {
+      return err
+   }
+
+   if completedWeeks == 0 {
+      return errors.New("power-rankings: No weeks have been completed yet")
+   }
+
+   seasonData, err := pEnv.loadSeasonData(nil, 0)
+
+   if err != nil {
+      return err
+   }
+
+   powerRankings, err := GetPowerRankings(seasonData, completedWeeks, pEnv.mConfig.RegularSeasonWeeks, *pEnv.mConfig.PowerRankingWeights)
+
+   if err != nil {
+      return err
+   }
+
+   return pEnv.output(powerRankings, powerRankings.Print)
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func runPlayoffOddsCommand(pEnv *CommandEnv, pArgs []string) error {
+
+   command, _ := GetCommand("playoff-odds")
+   err := parseCommandFlags(newCommandFlagSet(command, false), pArgs)
+
+   if err != nil {
+      return err
+   }
+
+   _, completedWeeks, err := pEnv.getCompletedWeeks()
+
+   if err != nil {
+      return err
+   }
+
+   if completedWeeks == pEnv.mConfig.RegularSeasonWeeks {
+      return errors.New("playoff-odds: The regular season is over")
+   }
+
+   seasonData, err := pEnv.loadSeasonData(nil, 0)
+
+   if err != nil {
+      return err
+   }
+
+   playoffOdds, err := getPlayoffOdds(pEnv, seasonData, completedWeeks)
+
+   if err != nil {
+      return err
+   }
+
+   return pEnv.output(playoffOdds, playoffOdds.Print)
+}
+
+//--------------------------------------------------------------------------------------------------
+// Without a configured seed each run uses a new one, which the odds report so the run can be repeated.
+//--------------------------------------------------------------------------------------------------
+func getPlayoffOdds(pEnv *CommandEnv, pSeasonData SeasonData, pCompletedWeeks int) (PlayoffOdds, error) {
+
+   config := pEnv.mConfig
+   playoffOddsOptions := PlayoffOddsOptions{Simulations: config.PlayoffSimulations, Seed: time.Now().UnixNano(), ProjectionBlend: config.ProjectionBlend}
+
+   if config.SimulationSeed != nil {
+      playoffOddsOptions.Seed = *config.SimulationSeed
+   }
+
+   if config.ProjectionBlend > 0.0 {
+      err := pSeasonData.LoadRosterProjections(pEnv.mContext)
+
+      if err != nil {
+         log.Print(err)
+      }
+   }
+
+   return GetPlayoffOdds(pSeasonData, pCompletedWeeks, config.RegularSeasonWeeks, playoffOddsOptions)
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func runBracketsCommand(pEnv *CommandEnv, pArgs []string) error {
+
+   command, _ := GetCommand("brackets")
+   err := parseCommandFlags(newCommandFlagSet(command, false), pArgs)
+
+   if err != nil {
+      return err
+   }
+
+   seasonData, err := pEnv.loadSeasonData(nil, PrizeDataBrackets)
+
+   if err != nil {
+      return err
+   }
+
+   bracketReports := getBracketReports(seasonData)
+
+   if len(bracketReports) == 0 {
+      log.Printf("The playoffs have not been seeded yet")
+   }
+
+   return pEnv.output(bracketReports, func() {
+      for _, bracketReport := range bracketReports {
+         bracketReport.Print()
+      }
+   })
+}
+
+//--------------------------------------------------------------------------------------------------
+// Sleeper only has brackets once the playoffs are seeded, so missing brackets are left out quietly.
+//--------------------------------------------------------------------------------------------------
+func getBracketReports(pSeasonData SeasonData) []BracketReport {
+   var bracketReports []BracketReport
+
+   for _, bracketType := range []BracketType{WinnersBracket, LosersBracket} {
+
+      bracketReport, err := GetBracketReport(pSeasonData, bracketType)
+
+      if err != nil {
+         if !errors.Is(err, ErrNotFound) {
+            log.Print(err)
+         }
+      } else if len(bracketReport.Rounds) > 0 {
+         bracketReports = append(bracketReports, bracketReport)
+      }
+   }
+
+   return bracketReports
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func runAwardsCommand(pEnv *CommandEnv, pArgs []string) error {
+
+   command, _ := GetCommand("awards")
+   err := parseCommandFlags(newCommandFlagSet(command, false), pArgs)
+
+   if err != nil {
+      return err
+   }
+
+   _, completedWeeks, err := pEnv.getCompletedWeeks()
+
+   if err != nil {
+      return err
+   }
+
+   seasonData, err := pEnv.loadSeasonData(nil, GetSeasonAwardsRequiredData())
+
+   if err != nil {
+      return err
+   }
+
+   seasonAwards := GetSeasonAwards(seasonData, completedWeeks)
+
+   return pEnv.output(seasonAwards, seasonAwards.Print)
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func runLedgerCommand(pEnv *CommandEnv, pArgs []string) error {
+
+   command, _ := GetCommand("ledger")
+   flagSet := newCommandFlagSet(command, false)
+   markSent := flagSet.String("mark-sent", "", "Mark the payout with this Id as sent")
+
+   err := parseCommandFlags(flagSet, pArgs)
+
+   if err != nil {
+      return err
+   }
+
+   if pEnv.mConfig.Ledger == nil {
+      return errors.New("ledger: Config.json has no Ledger")
+   }
+
+   summaries, seasonData, err := pEnv.getWeekSummaries(PrizeDataBrackets)
+
+   if err != nil {
+      return err
+   }
+
+   ledger, err := updateLedger(pEnv.mConfig, seasonData, summaries, *markSent)
+
+   if err != nil {
+      return err
+   }
+
+   return pEnv.output(struct {
+      Statements []OwnerStatement
+      Entries []LedgerEntry
+   }{ledger.GetStatements(), ledger.Entries}, ledger.Print)
+}
+
+//--------------------------------------------------------------------------------------------------
+// updateLedger posts the dues and every decided prize to the saved ledger. Weekly prizes are only
+// posted once their week is finalized.
+//--------------------------------------------------------------------------------------------------
+func updateLedger(pConfig Config, pSeasonData SeasonData, pSummaries []WeekSummary, pMarkSentId string) (Ledger, error) {
+
+   ledger, err := LoadLedger(pConfig.Ledger.File)
+
+   if err != nil {
+      return Ledger{}, err
+   }
+
+   ledger.PostDues(pSeasonData.mLeagueInfo, pConfig.Ledger.Dues)
+   ledger.PostWeekSummaries(pSeasonData.mLeagueInfo, pSummaries, pConfig.mWeekPayouts)
+
+   if winnersBracket, err := pSeasonData.GetBracket(WinnersBracket) ; err == nil {
+      ledger.PostFinalStandings(pSeasonData.mLeagueInfo, winnersBracket, pConfig.Ledger.FinalStandingsPayouts)
+   }
+
+   ledger.UpdateOwnerNames(pSeasonData.mLeagueInfo)
+
+   if pMarkSentId != "" {
+      err = ledger.MarkSent(pMarkSentId)
+
+      if err != nil {
+         return Ledger{}, err
+      }
+   }
+
+   return ledger, ledger.Save()
+}
+
+//--------------------------------------------------------------------------------------------------
+// runRecheckCommand recomputes every locked week and reports the differences without changing the
+// stored results, leaving it to the commissioner to decide whether to honour them.
+//--------------------------------------------------------------------------------------------------
+func runRecheckCommand(pEnv *CommandEnv, pArgs []string) error {
+
+   command, _ := GetCommand("recheck")
+   err := parseCommandFlags(newCommandFlagSet(command, false), pArgs)
+
+   if err != nil {
+      return err
+   }
+
+   leagueId, err := pEnv.getLeagueId()
+
+   if err != nil {
+      return err
+   }
+
+   lockedResults, err := NewResultsStore(pEnv.mConfig.ResultsDir, leagueId).GetLockedResults(pEnv.mConfig.mPrizeSchedule)
+
+   if err != nil {
+      log.Print(err)
+   }
+
+   var lockedPrizes []ScheduledPrize
+
+   for _, scheduledPrize := range pEnv.mConfig.mPrizeSchedule {
+      if _, isLocked := lockedResults[scheduledPrize.mWeek] ; isLocked {
+         lockedPrizes = append(lockedPrizes, scheduledPrize)
+      }
+   }
+
+   if len(lockedPrizes) == 0 {
+      log.Printf("No finalized weeks to recheck")
+   }
+
+   // Player names identify the players whose points changed
+   seasonData, err := pEnv.loadSeasonData(lockedPrizes, PrizeDataPlayers)
+
+   if err != nil {
+      return err
+   }
+
+   var recheckResults []RecheckResult
+
+   for _, scheduledPrize := range lockedPrizes {
+      recheckResults = append(recheckResults, RecheckWeek(seasonData, scheduledPrize.mPrize, lockedResults[scheduledPrize.mWeek], *pEnv.mConfig.RecheckTolerance))
+   }
+
+   return pEnv.output(recheckResults, func() {
+      for _, recheckResult := range recheckResults {
+         recheckResult.Print()
+      }
+   })
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func runLeaguesCommand(pEnv *CommandEnv, pArgs []string) error {
+
+   command, _ := GetCommand("leagues")
+   err := parseCommandFlags(newCommandFlagSet(command, false), pArgs)
+
+   if err != nil {
+      return err
+   }
+
+   userLeagues, err := pEnv.getUserLeagues()
+
+   if err != nil {
+      return err
+   }
+
+   return pEnv.output(userLeagues, func() {
+      printLeagues(pEnv.mConfig.Username, pEnv.mConfig.Year, userLeagues)
+   })
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func runPlayersCommand(pEnv *CommandEnv, pArgs []string) error {
+
+   command, _ := GetCommand("players")
+   flagSet := newCommandFlagSet(command, false)
+
+   err := flagSet.Parse(pArgs)
+
+   if err != nil {
+      return err
+   }
+
+   if flagSet.NArg() < 2 || flagSet.Arg(0) != "search" {
+      flagSet.Usage()
+      return errors.New("players: Expected search and a name")
+   }
+
+   players, err := pEnv.mClient.GetPlayers(pEnv.mContext)
+
+   if err != nil {
+      return err
+   }
+
+   query := strings.Join(flagSet.Args()[1:], " ")
+   searchResults := SearchPlayers(players, query)
+
+   return pEnv.output(searchResults, func() {
+      log.Printf("Players Matching %q", query)
+
+      for _, searchResult := range searchResults {
+         log.Printf("   %s: %s, %s, %s", searchResult.Player_id, searchResult.Name, searchResult.Position, searchResult.Team)
+      }
+   })
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func runPrizesCommand(pEnv *CommandEnv, pArgs []string) error {
+
+   command, _ := GetCommand("prizes")
+   err := parseCommandFlags(newCommandFlagSet(command, false), pArgs)
+
+   if err != nil {
+      return err
+   }
+
+   type prizeHelp struct {
+      Name string
+      Criteria string
+   }
+
+   var prizes []prizeHelp
+
+   for _, prize := range GetPrizes() {
+      prizes = append(prizes, prizeHelp{prize.Name(), prize.Criteria()})
+   }
+
+   return pEnv.output(prizes, func() {
+      for _, prize := range prizes {
+         log.Printf("%s - %s", prize.Name, prize.Criteria)
+      }
+   })
+}
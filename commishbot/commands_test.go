@@ -0,0 +1,221 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+//--------------------------------------------------------------------------------------------------
+// writeTestConfigFile writes a Config.json for the fixture league's user with pValues added.
+//--------------------------------------------------------------------------------------------------
+func writeTestConfigFile(pTest *testing.T, pValues map[string]any) string {
+
+   values := map[string]any{"Username": "commish", "Year": testYear, "ResultsDir": pTest.TempDir()}
+
+   for key, value := range pValues {
+      values[key] = value
+   }
+
+   configPath := filepath.Join(pTest.TempDir(), "Config.json")
+   configData, _ := json.Marshal(values)
+
+   err := os.WriteFile(configPath, configData, 0644)
+
+   if err != nil {
+      pTest.Fatal(err)
+   }
+
+   return configPath
+}
+
+//--------------------------------------------------------------------------------------------------
+// writeTestConfig reads back a Config.json for the fixture league's user with pValues added.
+//--------------------------------------------------------------------------------------------------
+func writeTestConfig(pTest *testing.T, pValues map[string]any) Config {
+
+   config, err := GetConfig(writeTestConfigFile(pTest, pValues))
+
+   if err != nil {
+      pTest.Fatalf("GetConfig: %s", err.Error())
+   }
+
+   return config
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func newTestCommandEnv(pTest *testing.T, pFormat OutputFormat) (*CommandEnv, *bytes.Buffer) {
+   _, client := newTestClient(pTest)
+
+   var output bytes.Buffer
+   env := &CommandEnv{mContext: context.Background(), mConfig: writeTestConfig(pTest, nil), mClient: client, mFormat: pFormat, mOutput: &output}
+
+   return env, &output
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func runTestCommand(pTest *testing.T, pEnv *CommandEnv, pArgs ...string) error {
+   command, hasCommand := GetCommand(pArgs[0])
+
+   if !hasCommand {
+      pTest.Fatalf("Command %s is not registered", pArgs[0])
+   }
+
+   return command.Run(pEnv, pArgs[1:])
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func TestSummaryCommand(pTest *testing.T) {
+
+   env, output := newTestCommandEnv(pTest, FormatJson)
+   err := runTestCommand(pTest, env, "summary", "--week", "1")
+
+   if err != nil {
+      pTest.Fatalf("summary: %s", err.Error())
+   }
+
+   var summaries []WeekSummary
+   err = json.Unmarshal(output.Bytes(), &summaries)
+
+   if err != nil {
+      pTest.Fatalf("Failed to decode %q: %s", output.String(), err.Error())
+   }
+
+   if len(summaries) != 1 || summaries[0].Week != 1 || len(summaries[0].PrizeEntries) == 0 || summaries[0].PrizeEntries[0].Owner != "Icemen" {
+      pTest.Errorf("Summary %+v, expected the Icemen to win week 1", summaries)
+   }
+
+   output.Reset()
+   err = runTestCommand(pTest, env, "summary", "--all")
+
+   if err != nil {
+      pTest.Fatalf("summary --all: %s", err.Error())
+   }
+
+   err = json.Unmarshal(output.Bytes(), &summaries)
+
+   if err != nil || len(summaries) != len(env.mConfig.mPrizeSchedule) {
+      pTest.Errorf("Got %d summaries, expected one per scheduled week", len(summaries))
+   }
+
+   if err := runTestCommand(pTest, env, "summary", "--week", "30") ; !errors.Is(err, ErrNotFound) {
+      pTest.Errorf("summary --week 30 gave %v, expected ErrNotFound", err)
+   }
+
+   if err := runTestCommand(pTest, env, "summary", "--week", "1", "--all") ; err == nil {
+      pTest.Errorf("summary --week 1 --all did not fail")
+   }
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func TestLeaguesAndPlayersCommands(pTest *testing.T) {
+
+   env, output := newTestCommandEnv(pTest, FormatJson)
+   err := runTestCommand(pTest, env, "leagues")
+
+   if err != nil {
+      pTest.Fatalf("leagues: %s", err.Error())
+   }
+
+   var leagues []League
+   err = json.Unmarshal(output.Bytes(), &leagues)
+
+   if err != nil || len(leagues) != 1 || leagues[0].League_id != testLeagueId {
+      pTest.Errorf("Leagues %+v, expected league %s", leagues, testLeagueId)
+   }
+
+   output.Reset()
+   err = runTestCommand(pTest, env, "players", "search", "BANDITSSON")
+
+   if err != nil {
+      pTest.Fatalf("players search: %s", err.Error())
+   }
+
+   var searchResults []PlayerSearchResult
+   err = json.Unmarshal(output.Bytes(), &searchResults)
+
+   if err != nil || len(searchResults) == 0 || searchResults[0].Name != "Alex Banditsson" {
+      pTest.Errorf("Search results %+v, expected Alex Banditsson first", searchResults)
+   }
+
+   if err := runTestCommand(pTest, env, "players", "find", "Alex") ; err == nil {
+      pTest.Errorf("players find did not fail")
+   }
+}
+
+//--------------------------------------------------------------------------------------------------
+// A league can be selected by name, and each league's JSON output is labelled when several are run.
+//--------------------------------------------------------------------------------------------------
+func TestCommandLeagueSelection(pTest *testing.T) {
+
+   env, output := newTestCommandEnv(pTest, FormatJson)
+   env.mLeagueSelectors = []string{"Office League"}
+
+   if _, err := env.getLeagueId() ; !errors.Is(err, ErrNotFound) {
+      pTest.Errorf("Unknown league gave %v, expected ErrNotFound", err)
+   }
+
+   env.mLeagueSelectors = []string{testLeagueId}
+   leagues, err := env.getSelectedLeagues()
+
+   if err != nil || len(leagues) != 1 {
+      pTest.Fatalf("Selected leagues %+v (%v), expected league %s", leagues, err, testLeagueId)
+   }
+
+   env.mLeagueSelectors = []string{leagues[0].Name}
+
+   if leagueId, err := env.getLeagueId() ; err != nil || leagueId != testLeagueId {
+      pTest.Errorf("League %q selected %s (%v), expected %s", leagues[0].Name, leagueId, err, testLeagueId)
+   }
+
+   err = runTestCommand(pTest, env.forLeague(leagues[0], true), "summary", "--week", "1")
+
+   if err != nil {
+      pTest.Fatalf("summary: %s", err.Error())
+   }
+
+   var leagueOutput struct {
+      League_id string
+      Output []WeekSummary
+   }
+
+   err = json.Unmarshal(output.Bytes(), &leagueOutput)
+
+   if err != nil || leagueOutput.League_id != testLeagueId || len(leagueOutput.Output) != 1 {
+      pTest.Errorf("League output %q, expected league %s's week 1 summary", output.String(), testLeagueId)
+   }
+}
+
+//--------------------------------------------------------------------------------------------------
+// The fixtures have no week 13 stats, so Touchdown Dance fails and its JSON summary carries the error.
+//--------------------------------------------------------------------------------------------------
+func TestSummaryCommandReportsErrors(pTest *testing.T) {
+
+   env, output := newTestCommandEnv(pTest, FormatJson)
+   env.mConfig = writeTestConfig(pTest, map[string]any{"PrizeSchedule": []map[string]any{{"Week": 13, "Prize": "Touchdown Dance"}}})
+
+   err := runTestCommand(pTest, env, "summary", "--week", "13")
+
+   if err != nil {
+      pTest.Fatalf("summary: %s", err.Error())
+   }
+
+   var summaries []WeekSummary
+   err = json.Unmarshal(output.Bytes(), &summaries)
+
+   if err != nil || len(summaries) != 1 || summaries[0].Error == "" {
+      pTest.Errorf("Summaries %q, expected week 13 with an error", output.String())
+   }
+}
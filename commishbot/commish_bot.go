@@ -1,657 +1,158 @@
 package main
 
 import (
+	"context"
 	"errors"
-	"io"
+	"flag"
+	"fmt"
 	"log"
-	"math"
-	"net/http"
-	"sort"
+	"os"
+	"strings"
 )
 
 //--------------------------------------------------------------------------------------------------
-//
+// stringListFlag collects the values of a flag given more than once.
 //--------------------------------------------------------------------------------------------------
-func main() {
-   config := GetConfig("Config.json")
-   log.Printf("%+v", config)
-
-   user := GetUser(config.Username)
-   userLeagues := GetUserLeagues(user.User_id, config.Year)
-
-   if len(userLeagues) > 0 {
-
-      leagueInfo := GetLeagueInfo(userLeagues[0].League_id)
-      players := GetPlayers()
-
-      Week1Summary(leagueInfo).Print()
-      Week2Summary(leagueInfo).Print()
-      Week3Summary(leagueInfo).Print()
-      Week4Summary(leagueInfo).Print()
-      Week5Summary(leagueInfo).Print()
-      Week6Summary(leagueInfo).Print()
-      Week7Summary(leagueInfo).Print()
-      Week8Summary(leagueInfo, players).Print()
-      Week9Summary(leagueInfo, players).Print()
-      Week10Summary(leagueInfo, config.Year).Print()
-      Week11Summary(leagueInfo, config.Year).Print()
-      Week12Summary(leagueInfo, config.Year).Print()
-      Week13Summary(leagueInfo).Print()
-      Week14Summary(leagueInfo, config.Year).Print()
-   }
-}
+type stringListFlag []string
 
 //--------------------------------------------------------------------------------------------------
 //
 //--------------------------------------------------------------------------------------------------
-func check(pE error) {
-   if pE != nil {
-      panic(pE)
-   }
+func (stringList *stringListFlag) String() string {
+   return strings.Join(*stringList, ", ")
 }
 
 //--------------------------------------------------------------------------------------------------
 //
 //--------------------------------------------------------------------------------------------------
-func GetHttpResponse(pRequest string) string {
-   resp, err := http.Get(pRequest)
-   check(err)
-
-   defer resp.Body.Close()
-   body, err := io.ReadAll(resp.Body)
-   check(err)
-
-   return string(body)
+func (stringList *stringListFlag) Set(pValue string) error {
+   *stringList = append(*stringList, pValue)
+   return nil
 }
 
 //--------------------------------------------------------------------------------------------------
 //
 //--------------------------------------------------------------------------------------------------
-func Week1Summary(pLeagueInfo LeagueInfo) WeekSummary {
-
-   var summary WeekSummary
-   summary.Week = 1
-   summary.Criteria = "Hot Start - Highest Starting Team Score"
-
-   matchups := GetMatchups(pLeagueInfo.mLeague.League_id, summary.Week)
-
-   for _, roster := range pLeagueInfo.mRosters {
-
-      matchupRoster, err := GetMatchupRoster(matchups, roster.Roster_id)
-
-      if err != nil {
-         summary.Err = err
-         return summary
-      }
-
-      var prizeEntry PrizeEntry
-      prizeEntry.Owner = pLeagueInfo.mDisplayNames[roster.Owner_id]
-      prizeEntry.Score = matchupRoster.GetTotalStarterPoints()
-
-      summary.PrizeEntries = append(summary.PrizeEntries, prizeEntry)
+func main() {
+   flag.Usage = func() {
+      fmt.Fprintf(flag.CommandLine.Output(), "Usage: commishbot [global flags] [command] [command flags]\n\nGlobal flags:\n")
+      flag.PrintDefaults()
+      fmt.Fprintln(flag.CommandLine.Output())
+      printCommandsHelp(flag.CommandLine.Output())
+      fmt.Fprintln(flag.CommandLine.Output())
+      printPrizesHelp(flag.CommandLine.Output())
    }
 
-   sort.Sort(summary.PrizeEntries)
-   summary.PrizeEntries.Reverse()
-
-   return summary
-}
-
-//--------------------------------------------------------------------------------------------------
-//
-//--------------------------------------------------------------------------------------------------
-func Week2Summary(pLeagueInfo LeagueInfo) WeekSummary {
-
-   var summary WeekSummary
-   summary.Week = 2
-   summary.Criteria = "Dead Weight - Lowest Starting Player Score, Wins Matchup"
-
-   matchups := GetMatchups(pLeagueInfo.mLeague.League_id, summary.Week)
-
-   for _, roster := range pLeagueInfo.mRosters {
-
-      matchupRoster, err := GetMatchupRoster(matchups, roster.Roster_id)
+   configPath := flag.String("config", "Config.json", "Read the config from this file")
+   var leagueSelectors stringListFlag
+   flag.Var(&leagueSelectors, "league", "Use the league with this Id or name instead of the config's Leagues (repeat for several leagues)")
+   flag.Var(&leagueSelectors, "league-id", "Same as --league")
+   year := flag.Int("year", 0, "Use this season instead of the config's Year")
+   formatName := flag.String("format", string(FormatText), "Output format: text or json")
+   refresh := flag.Bool("refresh", false, "Revalidate every cached Sleeper response")
+   offline := flag.Bool("offline", false, "Only use cached Sleeper responses")
+   flag.Parse()
 
-      if err != nil {
-         summary.Err = err
-         return summary
-      }
+   commandName := "report"
+   var commandArgs []string
 
-      matchupOpponentRoster, err :=  GetMatchupOpponentRoster(matchups, roster.Roster_id)
-
-      if err != nil {
-         summary.Err = err
-         return summary
-      }
-
-      var prizeEntry PrizeEntry
-      prizeEntry.Owner = pLeagueInfo.mDisplayNames[roster.Owner_id]
-      prizeEntry.Score = math.Inf(1)
-
-      totalStarterPoints := matchupRoster.GetTotalStarterPoints()
-      totalOpponentStarterPoints := matchupOpponentRoster.GetTotalStarterPoints()
-
-      if totalStarterPoints > totalOpponentStarterPoints {
-         for _, starterPoints := range matchupRoster.Starters_points {
-            prizeEntry.Score = math.Min(prizeEntry.Score, starterPoints)
-         }
-      }
-
-      summary.PrizeEntries = append(summary.PrizeEntries, prizeEntry)
+   if flag.NArg() > 0 {
+      commandName = flag.Arg(0)
+      commandArgs = flag.Args()[1:]
    }
 
-   sort.Sort(summary.PrizeEntries)
-
-   return summary
-}
-
-//--------------------------------------------------------------------------------------------------
-//
-//--------------------------------------------------------------------------------------------------
-func Week3Summary(pLeagueInfo LeagueInfo) WeekSummary {
-
-   var summary WeekSummary
-   summary.Week = 3
-   summary.Criteria = "MVP - Highest Starting Player Score"
-
-   matchups := GetMatchups(pLeagueInfo.mLeague.League_id, summary.Week)
-
-   for _, roster := range pLeagueInfo.mRosters {
-
-      matchupRoster, err := GetMatchupRoster(matchups, roster.Roster_id)
+   command, hasCommand := GetCommand(commandName)
 
-      if err != nil {
-         summary.Err = err
-         return summary
-      }
-
-      var prizeEntry PrizeEntry
-      prizeEntry.Owner = pLeagueInfo.mDisplayNames[roster.Owner_id]
-      prizeEntry.Score = math.Inf(-1)
-
-      for _, starterPoints := range matchupRoster.Starters_points {
-         prizeEntry.Score = math.Max(prizeEntry.Score, starterPoints)
-      }
-
-      summary.PrizeEntries = append(summary.PrizeEntries, prizeEntry)
+   if !hasCommand {
+      flag.Usage()
+      log.Fatalf("Unknown command %q", commandName)
    }
 
-   sort.Sort(summary.PrizeEntries)
-   summary.PrizeEntries.Reverse()
-
-   return summary
-}
-
-//--------------------------------------------------------------------------------------------------
-//
-//--------------------------------------------------------------------------------------------------
-func Week4Summary(pLeagueInfo LeagueInfo) WeekSummary {
-
-   var summary WeekSummary
-   summary.Week = 4
-   summary.Criteria = "Bench Warmers - Highest Team Bench Score"
-
-   matchups := GetMatchups(pLeagueInfo.mLeague.League_id, summary.Week)
-
-   for _, roster := range pLeagueInfo.mRosters {
-
-      matchupRoster, err := GetMatchupRoster(matchups, roster.Roster_id)
+   format, err := ParseOutputFormat(*formatName)
 
-      if err != nil {
-         summary.Err = err
-         return summary
-      }
-
-      var prizeEntry PrizeEntry
-      prizeEntry.Owner = pLeagueInfo.mDisplayNames[roster.Owner_id]
-      prizeEntry.Score = 0.0
-
-      benchPlayerPoints := matchupRoster.GetBenchPlayerPoints()
-
-      for _, benchPlayerPoints := range benchPlayerPoints {
-         prizeEntry.Score += benchPlayerPoints
-      }
-
-      summary.PrizeEntries = append(summary.PrizeEntries, prizeEntry)
+   if err != nil {
+      log.Fatal(err)
    }
 
-   sort.Sort(summary.PrizeEntries)
-   summary.PrizeEntries.Reverse()
-
-   return summary
-}
-
-//--------------------------------------------------------------------------------------------------
-//
-//--------------------------------------------------------------------------------------------------
-func Week5Summary(pLeagueInfo LeagueInfo) WeekSummary {
-
-   var summary WeekSummary
-   summary.Week = 5
-   summary.Criteria = "Biggest Loser - Highest Starting Team Score, Loses Matchup"
-
-   matchups := GetMatchups(pLeagueInfo.mLeague.League_id, summary.Week)
-
-   for _, roster := range pLeagueInfo.mRosters {
-
-      matchupRoster, err := GetMatchupRoster(matchups, roster.Roster_id)
-
-      if err != nil {
-         summary.Err = err
-         return summary
-      }
-
-      matchupOpponentRoster, err :=  GetMatchupOpponentRoster(matchups, roster.Roster_id)
-
-      if err != nil {
-         summary.Err = err
-         return summary
-      }
-
-      var prizeEntry PrizeEntry
-      prizeEntry.Owner = pLeagueInfo.mDisplayNames[roster.Owner_id]
-      prizeEntry.Score = math.Inf(-1)
-
-      totalStarterPoints := matchupRoster.GetTotalStarterPoints()
-      totalOpponentStarterPoints := matchupOpponentRoster.GetTotalStarterPoints()
-
-      if totalStarterPoints < totalOpponentStarterPoints {
-         prizeEntry.Score = totalStarterPoints
-      }
-
-      summary.PrizeEntries = append(summary.PrizeEntries, prizeEntry)
+   if *refresh && *offline {
+      log.Fatal("--refresh and --offline cannot be used together")
    }
 
-   sort.Sort(summary.PrizeEntries)
-   summary.PrizeEntries.Reverse()
-
-   return summary
-}
-
-//--------------------------------------------------------------------------------------------------
-//
-//--------------------------------------------------------------------------------------------------
-func Week6Summary(pLeagueInfo LeagueInfo) WeekSummary {
-
-   var summary WeekSummary
-   summary.Week = 6
-   summary.Criteria = "Photo Finish - Team With Closest Margin Of Victory"
-
-   matchups := GetMatchups(pLeagueInfo.mLeague.League_id, summary.Week)
-
-   for _, roster := range pLeagueInfo.mRosters {
-
-      matchupRoster, err := GetMatchupRoster(matchups, roster.Roster_id)
-
-      if err != nil {
-         summary.Err = err
-         return summary
-      }
-
-      matchupOpponentRoster, err :=  GetMatchupOpponentRoster(matchups, roster.Roster_id)
-
-      if err != nil {
-         summary.Err = err
-         return summary
-      }
-
-      var prizeEntry PrizeEntry
-      prizeEntry.Owner = pLeagueInfo.mDisplayNames[roster.Owner_id]
-      prizeEntry.Score = math.Inf(1)
-
-      totalStarterPoints := matchupRoster.GetTotalStarterPoints()
-      totalOpponentStarterPoints := matchupOpponentRoster.GetTotalStarterPoints()
-
-      if totalStarterPoints > totalOpponentStarterPoints {
-         prizeEntry.Score = totalStarterPoints - totalOpponentStarterPoints
-      }
-
-      summary.PrizeEntries = append(summary.PrizeEntries, prizeEntry)
+   if command.NeedsFreshData && *offline {
+      log.Fatalf("%s and --offline cannot be used together", command.Name)
    }
 
-   sort.Sort(summary.PrizeEntries)
-
-   return summary
-}
-
-//--------------------------------------------------------------------------------------------------
-//
-//--------------------------------------------------------------------------------------------------
-func Week7Summary(pLeagueInfo LeagueInfo) WeekSummary {
-
-   var summary WeekSummary
-   summary.Week = 7
-   summary.Criteria = "Biggest Blowout - Team With The Largest Margin of Victory"
-
-   matchups := GetMatchups(pLeagueInfo.mLeague.League_id, summary.Week)
-
-   for _, roster := range pLeagueInfo.mRosters {
-
-      matchupRoster, err := GetMatchupRoster(matchups, roster.Roster_id)
-
-      if err != nil {
-         summary.Err = err
-         return summary
-      }
+   config, err := GetConfig(*configPath)
 
-      matchupOpponentRoster, err :=  GetMatchupOpponentRoster(matchups, roster.Roster_id)
-
-      if err != nil {
-         summary.Err = err
-         return summary
-      }
-
-      var prizeEntry PrizeEntry
-      prizeEntry.Owner = pLeagueInfo.mDisplayNames[roster.Owner_id]
-      prizeEntry.Score = math.Inf(-1)
-
-      totalStarterPoints := matchupRoster.GetTotalStarterPoints()
-      totalOpponentStarterPoints := matchupOpponentRoster.GetTotalStarterPoints()
-
-      if totalStarterPoints > totalOpponentStarterPoints {
-         prizeEntry.Score = totalStarterPoints - totalOpponentStarterPoints
-      }
-
-      summary.PrizeEntries = append(summary.PrizeEntries, prizeEntry)
+   if err != nil {
+      log.Fatal(err)
    }
 
-   sort.Sort(summary.PrizeEntries)
-   summary.PrizeEntries.Reverse()
-
-   return summary
-}
-
-//--------------------------------------------------------------------------------------------------
-//
-//--------------------------------------------------------------------------------------------------
-func Week8Summary(pLeagueInfo LeagueInfo, pPlayers map[string]Player) WeekSummary {
-
-   var summary WeekSummary
-   summary.Week = 8
-   summary.Criteria = "Best Manager - Team Closest To A Perfect Lineup Based On Their Roster"
-
-   matchups := GetMatchups(pLeagueInfo.mLeague.League_id, summary.Week)
-
-   for _, roster := range pLeagueInfo.mRosters {
-
-      matchupRoster, err := GetMatchupRoster(matchups, roster.Roster_id)
-
-      if err != nil {
-         summary.Err = err
-         return summary
-      }
-
-      var prizeEntry PrizeEntry
-      prizeEntry.Owner = pLeagueInfo.mDisplayNames[roster.Owner_id]
-
-      totalStarterPoints := matchupRoster.GetTotalStarterPoints()
-      maxRosterPoints := matchupRoster.GetMaxRosterPoints(pPlayers, pLeagueInfo.mLeague.mRosterPositionCounts)
-      prizeEntry.Score = totalStarterPoints / maxRosterPoints * 100.0
-
-      summary.PrizeEntries = append(summary.PrizeEntries, prizeEntry)
+   if *year != 0 {
+      config.Year = *year
    }
 
-   sort.Sort(summary.PrizeEntries)
-   summary.PrizeEntries.Reverse()
-
-   return summary
-}
-
-//--------------------------------------------------------------------------------------------------
-//
-//--------------------------------------------------------------------------------------------------
-func Week9Summary(pLeagueInfo LeagueInfo, pPlayers map[string]Player) WeekSummary {
-
-   var summary WeekSummary
-   summary.Week = 9
-   summary.Criteria = "Worst Manager - Team Farthest From A Perfect Lineup Based On Their Roster"
-
-   matchups := GetMatchups(pLeagueInfo.mLeague.League_id, summary.Week)
-
-   for _, roster := range pLeagueInfo.mRosters {
-
-      matchupRoster, err := GetMatchupRoster(matchups, roster.Roster_id)
-
-      if err != nil {
-         summary.Err = err
-         return summary
-      }
-
-      var prizeEntry PrizeEntry
-      prizeEntry.Owner = pLeagueInfo.mDisplayNames[roster.Owner_id]
-
-      totalStarterPoints := matchupRoster.GetTotalStarterPoints()
-      maxRosterPoints := matchupRoster.GetMaxRosterPoints(pPlayers, pLeagueInfo.mLeague.mRosterPositionCounts)
-      prizeEntry.Score = totalStarterPoints / maxRosterPoints * 100.0
-
-      summary.PrizeEntries = append(summary.PrizeEntries, prizeEntry)
+   if *refresh {
+      config.CacheMode = CacheModeRefresh
    }
 
-   sort.Sort(summary.PrizeEntries)
-
-   return summary
-}
-
-//--------------------------------------------------------------------------------------------------
-//
-//--------------------------------------------------------------------------------------------------
-func Week10Summary(pLeagueInfo LeagueInfo, pYear int) WeekSummary {
-
-   var summary WeekSummary
-   summary.Week = 10
-   summary.Criteria = "Overachiver - Team With The Most Points Over Their Weekly Projection"
-
-   matchups := GetMatchups(pLeagueInfo.mLeague.League_id, summary.Week)
-
-   for _, roster := range pLeagueInfo.mRosters {
-
-      matchupRoster, err := GetMatchupRoster(matchups, roster.Roster_id)
-
-      if err != nil {
-         summary.Err = err
-         return summary
-      }
-
-      starterPlayerPoints := matchupRoster.GetStarterPlayerPoints()
-
-      var prizeEntry PrizeEntry
-      prizeEntry.Owner = pLeagueInfo.mDisplayNames[roster.Owner_id]
-      prizeEntry.Score = 0.0
-
-      for _, starter := range matchupRoster.Starters {
-
-         starterProjection, err := GetProjectedPlayerWeekScore(starter, pYear, summary.Week, pLeagueInfo.mLeague.Scoring_settings)
-
-         if err != nil {
-            summary.Err = err
-            return summary
-         }
-
-         starterPoints, hasStarterPoints := starterPlayerPoints[starter]
-
-         if !hasStarterPoints {
-            summary.Err = errors.New("Failed to retrieve player " + starter + " points")
-            return summary
-         }
-
-         prizeEntry.Score += (starterPoints - starterProjection)
-      }
-
-      summary.PrizeEntries = append(summary.PrizeEntries, prizeEntry)
+   if *offline {
+      config.CacheMode = CacheModeOffline
    }
 
-   sort.Sort(summary.PrizeEntries)
-   summary.PrizeEntries.Reverse()
-
-   return summary
-}
-
-//--------------------------------------------------------------------------------------------------
-//
-//--------------------------------------------------------------------------------------------------
-func Week11Summary(pLeagueInfo LeagueInfo, pYear int) WeekSummary {
-
-   var summary WeekSummary
-   summary.Week = 11
-   summary.Criteria = "Underperformer - Team With The Most Points Under Their Weekly Projection"
-
-   matchups := GetMatchups(pLeagueInfo.mLeague.League_id, summary.Week)
-
-   for _, roster := range pLeagueInfo.mRosters {
-
-      matchupRoster, err := GetMatchupRoster(matchups, roster.Roster_id)
-
-      if err != nil {
-         summary.Err = err
-         return summary
-      }
-
-      starterPlayerPoints := matchupRoster.GetStarterPlayerPoints()
-
-      var prizeEntry PrizeEntry
-      prizeEntry.Owner = pLeagueInfo.mDisplayNames[roster.Owner_id]
-      prizeEntry.Score = 0.0
-
-      for _, starter := range matchupRoster.Starters {
-
-         starterProjection, err := GetProjectedPlayerWeekScore(starter, pYear, summary.Week, pLeagueInfo.mLeague.Scoring_settings)
-
-         if err != nil {
-            summary.Err = err
-            return summary
-         }
-
-         starterPoints, hasStarterPoints := starterPlayerPoints[starter]
-
-         if !hasStarterPoints {
-            summary.Err = errors.New("Failed to retrieve player " + starter + " points")
-            return summary
-         }
-
-         prizeEntry.Score += (starterPoints - starterProjection)
-      }
+   log.Printf("%+v", config)
 
-      summary.PrizeEntries = append(summary.PrizeEntries, prizeEntry)
+   env := &CommandEnv{
+      mContext: context.Background(),
+      mConfig: config,
+      mClient: NewCommandSleeperClient(config, command),
+      mFormat: format,
+      mOutput: os.Stdout,
+      mLeagueSelectors: config.Leagues,
    }
 
-   sort.Sort(summary.PrizeEntries)
-
-   return summary
-}
-
-//--------------------------------------------------------------------------------------------------
-//
-//--------------------------------------------------------------------------------------------------
-func Week12Summary(pLeagueInfo LeagueInfo, pYear int) WeekSummary {
-
-   var summary WeekSummary
-   summary.Week = 12
-   summary.Criteria = "Butterfingers - Most Starting Team Fumbles"
-
-   matchups := GetMatchups(pLeagueInfo.mLeague.League_id, summary.Week)
-   playerStats := GetPlayerStats(pYear, summary.Week)
-
-   for _, roster := range pLeagueInfo.mRosters {
-
-      matchupRoster, err := GetMatchupRoster(matchups, roster.Roster_id)
-
-      if err != nil {
-         summary.Err = err
-         return summary
-      }
+   if len(leagueSelectors) > 0 {
+      env.mLeagueSelectors = leagueSelectors
+   }
 
-      var prizeEntry PrizeEntry
-      prizeEntry.Owner = pLeagueInfo.mDisplayNames[roster.Owner_id]
-      prizeEntry.Score = 0.0
+   if !command.PerLeague {
+      err = command.Run(env, commandArgs)
 
-      for _, starter := range matchupRoster.Starters {
-         numFumbles := GetNumFumbles(playerStats, starter)
-         prizeEntry.Score += numFumbles
+      if err != nil && !errors.Is(err, flag.ErrHelp) {
+         log.Fatal(err)
       }
 
-      summary.PrizeEntries = append(summary.PrizeEntries, prizeEntry)
+      return
    }
 
-   sort.Sort(summary.PrizeEntries)
-   summary.PrizeEntries.Reverse()
-
-   return summary
-}
-
-//--------------------------------------------------------------------------------------------------
-//
-//--------------------------------------------------------------------------------------------------
-func Week13Summary(pLeagueInfo LeagueInfo) WeekSummary {
-
-   var summary WeekSummary
-   summary.Week = 13
-   summary.Criteria = "Blackjack - Staring Player Score Closest to 21 Without Going Over"
+   leagues, err := env.getSelectedLeagues()
 
-   matchups := GetMatchups(pLeagueInfo.mLeague.League_id, summary.Week)
+   if err != nil {
+      log.Fatal(err)
+   }
 
-   for _, roster := range pLeagueInfo.mRosters {
+   // A league that fails does not stop the others
+   hasFailed := false
 
-      matchupRoster, err := GetMatchupRoster(matchups, roster.Roster_id)
+   for _, league := range leagues {
 
-      if err != nil {
-         summary.Err = err
-         return summary
+      if len(leagues) > 1 {
+         log.Printf("League %s (Id: %s)", league.Name, league.League_id)
       }
 
-      var prizeEntry PrizeEntry
-      prizeEntry.Owner = pLeagueInfo.mDisplayNames[roster.Owner_id]
-      prizeEntry.Score = math.Inf(-1)
+      err = command.Run(env.forLeague(league, len(leagues) > 1), commandArgs)
 
-      for _, starterPoints := range matchupRoster.Starters_points {
-         if starterPoints <= 21.0 && prizeEntry.Score < starterPoints {
-            prizeEntry.Score = starterPoints
-         }
+      if errors.Is(err, flag.ErrHelp) {
+         return
       }
 
-      summary.PrizeEntries = append(summary.PrizeEntries, prizeEntry)
-   }
-
-   sort.Sort(summary.PrizeEntries)
-   summary.PrizeEntries.Reverse()
-
-   return summary
-}
-
-//--------------------------------------------------------------------------------------------------
-//
-//--------------------------------------------------------------------------------------------------
-func Week14Summary(pLeagueInfo LeagueInfo, pYear int) WeekSummary {
-
-   var summary WeekSummary
-   summary.Week = 14
-   summary.Criteria = "Touchdown Dance - Team With The Most Touchdowns (Excludes QB Passing Touchdowns)"
-
-   matchups := GetMatchups(pLeagueInfo.mLeague.League_id, summary.Week)
-   playerStats := GetPlayerStats(pYear, summary.Week)
-
-   for _, roster := range pLeagueInfo.mRosters {
-
-      matchupRoster, err := GetMatchupRoster(matchups, roster.Roster_id)
-
       if err != nil {
-         summary.Err = err
-         return summary
-      }
-
-      var prizeEntry PrizeEntry
-      prizeEntry.Owner = pLeagueInfo.mDisplayNames[roster.Owner_id]
-      prizeEntry.Score = 0.0
-
-      for _, starter := range matchupRoster.Starters {
-         numNonPassingTds := GetNumNonPassingTds(playerStats, starter)
-         prizeEntry.Score += numNonPassingTds
+         log.Print(err)
+         hasFailed = true
       }
-
-      summary.PrizeEntries = append(summary.PrizeEntries, prizeEntry)
    }
 
-   sort.Sort(summary.PrizeEntries)
-   summary.PrizeEntries.Reverse()
-
-   return summary
+   if hasFailed {
+      os.Exit(1)
+   }
 }
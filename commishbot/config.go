@@ -2,29 +2,322 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
 )
 
+const defaultRegularSeasonWeeks = 14
+const defaultPlayoffWeeks = 3
+const defaultLedgerFile = "Ledger.json"
+
+var defaultPrizeSchedule = []string{
+   "Hot Start",
+   "Dead Weight",
+   "MVP",
+   "Bench Warmers",
+   "Biggest Loser",
+   "Photo Finish",
+   "Biggest Blowout",
+   "Best Manager",
+   "Worst Manager",
+   "Overachiver",
+   "Underperformer",
+   "Butterfingers",
+   "Blackjack",
+   "Touchdown Dance",
+}
+
 //--------------------------------------------------------------------------------------------------
 //
 //--------------------------------------------------------------------------------------------------
 type Config struct {
    Username string
    Year int
+   RegularSeasonWeeks int
+   PlayoffWeeks int
+   Leagues []string
+   PrizeSchedule []PrizeScheduleEntry
+   SleeperBaseUrl string
+   SleeperProjectionsBaseUrl string
+   StatsSnapshotDir string
+   CacheDir string
+   ResultsDir string
+   RecheckTolerance *float64
+   GameTimesFile string
+   RolloverPolicy string
+   PowerRankingWeights *PowerRankingWeights
+   PlayoffSimulations int
+   SimulationSeed *int64
+   ProjectionBlend float64
+   Ledger *LedgerConfig
+
+   CacheMode CacheMode `json:"-"`
+
+   mPrizeSchedule []ScheduledPrize
+   mRolloverPolicy RolloverPolicy
+   mGameTimes map[int]WeekGameTimes
+   mWeekPayouts map[int]float64
+}
+
+//--------------------------------------------------------------------------------------------------
+// WeeklyPrizePayout is paid for every scheduled prize that does not set its own Payout, and
+// FinalStandingsPayouts are paid to the champion first.
+//--------------------------------------------------------------------------------------------------
+type LedgerConfig struct {
+   File string
+   Dues float64
+   WeeklyPrizePayout float64
+   FinalStandingsPayouts []float64
 }
 
 //--------------------------------------------------------------------------------------------------
 //
 //--------------------------------------------------------------------------------------------------
-func GetConfig(pFilePath string) Config {
-   file, _ := os.Open(pFilePath)
+type PrizeScheduleEntry struct {
+   Week int
+   Prize string
+   Params map[string]json.RawMessage
+   TieBreakers []string
+   Payout *float64
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type ScheduledPrize struct {
+   mWeek int
+   mPrize Prize
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func GetConfig(pFilePath string) (Config, error) {
+   file, err := os.Open(pFilePath)
+
+   if err != nil {
+      return Config{}, fmt.Errorf("GetConfig: %w", err)
+   }
+
    defer file.Close()
 
    var config Config
    decoder := json.NewDecoder(file)
 
-   err := decoder.Decode(&config)
-   check(err)
+   err = decoder.Decode(&config)
+
+   if err != nil {
+      return Config{}, fmt.Errorf("GetConfig: Failed to decode %s: %w", pFilePath, err)
+   }
+
+   if config.SleeperBaseUrl == "" {
+      config.SleeperBaseUrl = DefaultSleeperBaseUrl
+   }
+
+   if config.SleeperProjectionsBaseUrl == "" {
+      config.SleeperProjectionsBaseUrl = DefaultSleeperProjectionsBaseUrl
+   }
+
+   if config.CacheDir == "" {
+      config.CacheDir = getDefaultCacheDir()
+   }
+
+   if config.ResultsDir == "" {
+      config.ResultsDir = defaultResultsDir
+   }
+
+   // Zero is a valid tolerance, which only an absent value defaults
+   if config.RecheckTolerance == nil {
+      config.RecheckTolerance = new(float64)
+      *config.RecheckTolerance = defaultRecheckTolerance
+   }
+
+   if config.RegularSeasonWeeks == 0 {
+      config.RegularSeasonWeeks = defaultRegularSeasonWeeks
+   }
+
+   if config.PlayoffWeeks == 0 {
+      config.PlayoffWeeks = defaultPlayoffWeeks
+   }
+
+   if len(config.PrizeSchedule) == 0 {
+      for idx, prizeName := range defaultPrizeSchedule {
+         config.PrizeSchedule = append(config.PrizeSchedule, PrizeScheduleEntry{Week: idx+1, Prize: prizeName})
+      }
+   }
+
+   config.mRolloverPolicy, err = ParseRolloverPolicy(config.RolloverPolicy)
+
+   if err != nil {
+      return Config{}, fmt.Errorf("GetConfig: Invalid config in %s: %w", pFilePath, err)
+   }
+
+   if config.GameTimesFile != "" {
+      config.mGameTimes, err = LoadGameTimes(config.GameTimesFile)
+
+      if err != nil {
+         return Config{}, fmt.Errorf("GetConfig: Invalid config in %s: %w", pFilePath, err)
+      }
+   }
+
+   if config.PowerRankingWeights == nil {
+      config.PowerRankingWeights = &PowerRankingWeights{}
+      *config.PowerRankingWeights = DefaultPowerRankingWeights
+   }
+
+   err = config.PowerRankingWeights.validate()
+
+   if err != nil {
+      return Config{}, fmt.Errorf("GetConfig: Invalid config in %s: %w", pFilePath, err)
+   }
+
+   if config.PlayoffSimulations == 0 {
+      config.PlayoffSimulations = defaultPlayoffSimulations
+   }
+
+   if config.PlayoffSimulations < 0 {
+      return Config{}, fmt.Errorf("GetConfig: Invalid config in %s: playoff simulations must be positive", pFilePath)
+   }
+
+   if *config.RecheckTolerance < 0.0 {
+      return Config{}, fmt.Errorf("GetConfig: Invalid config in %s: recheck tolerance cannot be negative", pFilePath)
+   }
+
+   if config.ProjectionBlend < 0.0 || config.ProjectionBlend > 1.0 {
+      return Config{}, fmt.Errorf("GetConfig: Invalid config in %s: projection blend must be between 0 and 1", pFilePath)
+   }
+
+   if config.Ledger != nil {
+      if config.Ledger.File == "" {
+         config.Ledger.File = defaultLedgerFile
+      }
+
+      err = config.Ledger.validate()
+
+      if err != nil {
+         return Config{}, fmt.Errorf("GetConfig: Invalid ledger in %s: %w", pFilePath, err)
+      }
+   }
+
+   err = config.populatePrizeSchedule()
+
+   if err != nil {
+      return Config{}, fmt.Errorf("GetConfig: Invalid prize schedule in %s: %w", pFilePath, err)
+   }
+
+   return config, nil
+}
+
+//--------------------------------------------------------------------------------------------------
+// GetSeasonWeeks returns the number of weeks in the regular season and the playoffs together.
+//--------------------------------------------------------------------------------------------------
+func (config Config) GetSeasonWeeks() int {
+   return config.RegularSeasonWeeks + config.PlayoffWeeks
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func getDefaultCacheDir() string {
+   userCacheDir, err := os.UserCacheDir()
+
+   if err != nil {
+      return ""
+   }
+
+   return filepath.Join(userCacheDir, "commishbot")
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (config *Config) populatePrizeSchedule() error {
+
+   var errs []error
+   scheduledWeeks := make(map[int]bool)
+   config.mWeekPayouts = make(map[int]float64)
+
+   for _, entry := range config.PrizeSchedule {
+
+      if entry.Week < 1 || entry.Week > config.GetSeasonWeeks() {
+         errs = append(errs, fmt.Errorf("week %d is outside the season (weeks 1-%d)", entry.Week, config.GetSeasonWeeks()))
+      }
+
+      if scheduledWeeks[entry.Week] {
+         errs = append(errs, fmt.Errorf("week %d is scheduled more than once", entry.Week))
+      }
+
+      scheduledWeeks[entry.Week] = true
+
+      prize, hasPrize := GetPrize(entry.Prize)
+
+      if !hasPrize {
+         errs = append(errs, fmt.Errorf("week %d has unknown prize %q", entry.Week, entry.Prize))
+         continue
+      }
+
+      prize, err := ConfigurePrize(prize, entry.Params)
+
+      if err != nil {
+         errs = append(errs, fmt.Errorf("week %d prize %q: %w", entry.Week, entry.Prize, err))
+         continue
+      }
+
+      if entry.TieBreakers != nil {
+         tieBreakers, err := getTieBreakers(entry.TieBreakers)
+
+         if err != nil {
+            errs = append(errs, fmt.Errorf("week %d prize %q: %w", entry.Week, entry.Prize, err))
+            continue
+         }
+
+         prize = WithTieBreakers(prize, tieBreakers)
+      }
+
+      if entry.Payout != nil && *entry.Payout < 0.0 {
+         errs = append(errs, fmt.Errorf("week %d prize %q: payout cannot be negative", entry.Week, entry.Prize))
+         continue
+      }
+
+      if entry.Payout != nil {
+         config.mWeekPayouts[entry.Week] = *entry.Payout
+      } else if config.Ledger != nil {
+         config.mWeekPayouts[entry.Week] = config.Ledger.WeeklyPrizePayout
+      }
+
+      config.mPrizeSchedule = append(config.mPrizeSchedule, ScheduledPrize{entry.Week, prize})
+   }
+
+   sort.Slice(config.mPrizeSchedule, func(i, j int) bool {
+      return config.mPrizeSchedule[i].mWeek < config.mPrizeSchedule[j].mWeek
+   })
+
+   return errors.Join(errs...)
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (ledgerConfig LedgerConfig) validate() error {
+
+   var errs []error
+
+   if ledgerConfig.Dues < 0.0 {
+      errs = append(errs, errors.New("dues cannot be negative"))
+   }
+
+   if ledgerConfig.WeeklyPrizePayout < 0.0 {
+      errs = append(errs, errors.New("weekly prize payout cannot be negative"))
+   }
+
+   for idx, payout := range ledgerConfig.FinalStandingsPayouts {
+      if payout < 0.0 {
+         errs = append(errs, fmt.Errorf("%s place payout cannot be negative", getOrdinal(idx+1)))
+      }
+   }
 
-   return config
+   return errors.Join(errs...)
 }
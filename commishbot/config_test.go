@@ -0,0 +1,77 @@
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func TestRecheckTolerance(pTest *testing.T) {
+
+   if tolerance := *writeTestConfig(pTest, nil).RecheckTolerance ; tolerance != defaultRecheckTolerance {
+      pTest.Errorf("Default recheck tolerance %g, expected %g", tolerance, defaultRecheckTolerance)
+   }
+
+   if tolerance := *writeTestConfig(pTest, map[string]any{"RecheckTolerance": 0}).RecheckTolerance ; tolerance != 0.0 {
+      pTest.Errorf("Recheck tolerance %g, expected the configured 0", tolerance)
+   }
+
+   configPath := filepath.Join(pTest.TempDir(), "Config.json")
+   err := os.WriteFile(configPath, []byte(`{"Username": "commish", "Year": 2023, "RecheckTolerance": -1}`), 0644)
+
+   if err != nil {
+      pTest.Fatal(err)
+   }
+
+   if _, err = GetConfig(configPath) ; err == nil {
+      pTest.Errorf("A negative recheck tolerance was accepted")
+   }
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func TestPrizeScheduleValidation(pTest *testing.T) {
+
+   testCases := []struct {
+      name string
+      prizeSchedule []map[string]any
+      expectedError string
+   }{
+      {"Week Zero", []map[string]any{{"Week": 0, "Prize": "MVP"}}, "week 0 is outside the season (weeks 1-17)"},
+      {"Week After The Playoffs", []map[string]any{{"Week": 18, "Prize": "MVP"}}, "week 18 is outside the season (weeks 1-17)"},
+      {"Duplicate Week", []map[string]any{{"Week": 3, "Prize": "MVP"}, {"Week": 3, "Prize": "Hot Start"}}, "week 3 is scheduled more than once"},
+      {"Unknown Prize", []map[string]any{{"Week": 1, "Prize": "Most Improved"}}, `week 1 has unknown prize "Most Improved"`},
+      {"Params For A Fixed Prize", []map[string]any{{"Week": 1, "Prize": "MVP", "Params": map[string]any{"Target": 21}}}, "prize does not accept parameters"},
+      {"Unknown Param", []map[string]any{{"Week": 1, "Prize": "Blackjack", "Params": map[string]any{"Limit": 21}}}, "unknown parameter Limit"},
+      {"Invalid Param", []map[string]any{{"Week": 1, "Prize": "Blackjack", "Params": map[string]any{"Target": -21}}}, "Target must be a positive number"},
+      {"Unknown Tie-Breaker", []map[string]any{{"Week": 1, "Prize": "MVP", "TieBreakers": []string{"Coin Toss"}}}, `unknown tie-breaker "Coin Toss"`},
+      {"Negative Payout", []map[string]any{{"Week": 1, "Prize": "MVP", "Payout": -5}}, "payout cannot be negative"},
+   }
+
+   for _, testCase := range testCases {
+
+      _, err := GetConfig(writeTestConfigFile(pTest, map[string]any{"PrizeSchedule": testCase.prizeSchedule}))
+
+      if err == nil || !strings.Contains(err.Error(), testCase.expectedError) {
+         pTest.Errorf("%s: Error %v, expected %q", testCase.name, err, testCase.expectedError)
+      }
+   }
+
+   // Every invalid entry is reported, not only the first
+   _, err := GetConfig(writeTestConfigFile(pTest, map[string]any{"PrizeSchedule": []map[string]any{{"Week": 0, "Prize": "MVP"}, {"Week": 1, "Prize": "Most Improved"}}}))
+
+   if err == nil || !strings.Contains(err.Error(), "week 0") || !strings.Contains(err.Error(), "Most Improved") {
+      pTest.Errorf("Error %v, expected both invalid entries", err)
+   }
+
+   if _, err := GetConfig(filepath.Join(pTest.TempDir(), "Missing.json")) ; !errors.Is(err, fs.ErrNotExist) || !strings.HasPrefix(err.Error(), "GetConfig: ") {
+      pTest.Errorf("Missing config gave %v, expected a wrapped fs.ErrNotExist", err)
+   }
+}
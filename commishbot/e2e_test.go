@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"testing"
+
+	"commish_bot/sleeperfake"
+)
+
+const testLeagueId = "1000"
+const testYear = 2023
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func newTestClient(pTest *testing.T) (*sleeperfake.Server, *SleeperClient) {
+   server := sleeperfake.NewServer("testdata/league10")
+   pTest.Cleanup(server.Close)
+
+   client := NewSleeperClient(server.BaseUrl(), server.ProjectionsBaseUrl(), server.Client())
+   client.SetRateLimiter(nil)
+
+   return server, client
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func makeDefaultTestSchedule(pTest *testing.T) []ScheduledPrize {
+   var prizeSchedule []ScheduledPrize
+
+   for idx, prizeName := range defaultPrizeSchedule {
+      prize, hasPrize := GetPrize(prizeName)
+
+      if !hasPrize {
+         pTest.Fatalf("Prize %s is not registered", prizeName)
+      }
+
+      prizeSchedule = append(prizeSchedule, ScheduledPrize{idx+1, prize})
+   }
+
+   return prizeSchedule
+}
+
+//--------------------------------------------------------------------------------------------------
+// newTestSeason loads the fixture league's season from a new fake server for pPrizeSchedule.
+//--------------------------------------------------------------------------------------------------
+func newTestSeason(pTest *testing.T, pPrizeSchedule []ScheduledPrize) (*sleeperfake.Server, SeasonData) {
+   server, client := newTestClient(pTest)
+   seasonData, err := LoadSeasonData(context.Background(), client, testLeagueId, testYear, MakeSeasonDataOptions(pPrizeSchedule))
+
+   if err != nil {
+      pTest.Fatalf("LoadSeasonData: %s", err.Error())
+   }
+
+   return server, seasonData
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func findPrizeEntry(pPrizeEntries PrizeEntries, pOwner string) *PrizeEntry {
+   for idx := range pPrizeEntries {
+      if pPrizeEntries[idx].Owner == pOwner {
+         return &pPrizeEntries[idx]
+      }
+   }
+
+   return nil
+}
+
+//--------------------------------------------------------------------------------------------------
+// The fixture league covers an empty starting slot in weeks 1, 10 and 13, a tied matchup in weeks
+// 2 and 7, two teams on a bye in week 5 and two teams without an opponent in week 6. Teams with equal
+// scores are ordered by the prize's tie-breakers, and teams that do not qualify are listed separately
+// in roster order.
+//--------------------------------------------------------------------------------------------------
+func TestDefaultScheduleSummaries(pTest *testing.T) {
+
+   expectedSummaries := []struct {
+      week int
+      winningScore float64
+      owners []string
+   }{
+      {1, 142.77, []string{"Icemen", "Bandits", "Aces", "Jaguars", "Eagles", "Falcons", "Giants", "Dragons", "Cobras", "Hornets"}},
+      {2, -1.76, []string{"Jaguars", "Giants", "Dragons", "Hornets"}},
+      {3, 29.99, []string{"Icemen", "Eagles", "Aces", "Dragons", "Bandits", "Cobras", "Jaguars", "Giants", "Hornets", "Falcons"}},
+      {4, 82.5, []string{"Aces", "Dragons", "Falcons", "Icemen", "Bandits", "Giants", "Hornets", "Eagles", "Jaguars", "Cobras"}},
+      {5, 113.56, []string{"Cobras", "Bandits", "Aces", "Falcons"}},
+      {6, 8.08, []string{"Dragons", "Eagles", "Icemen", "Falcons"}},
+      {7, 23.32, []string{"Jaguars", "Cobras", "Hornets", "Icemen"}},
+      {8, 96.8990, []string{"Dragons", "Giants", "Jaguars", "Eagles", "Aces", "Hornets", "Falcons", "Bandits", "Cobras", "Icemen"}},
+      {9, 60.7050, []string{"Giants", "Falcons", "Cobras", "Hornets", "Bandits", "Dragons", "Jaguars", "Aces", "Eagles", "Icemen"}},
+      {10, 72.14, []string{"Giants", "Jaguars", "Bandits", "Eagles", "Falcons", "Hornets", "Dragons", "Icemen", "Aces", "Cobras"}},
+      {11, -13.35, []string{"Cobras", "Icemen", "Hornets", "Bandits", "Dragons", "Falcons", "Aces", "Jaguars", "Giants", "Eagles"}},
+      {12, 8.0, []string{"Aces", "Bandits", "Hornets", "Jaguars", "Cobras", "Icemen", "Giants", "Dragons", "Eagles", "Falcons"}},
+      {13, 20.79, []string{"Dragons", "Bandits", "Cobras", "Aces", "Eagles", "Jaguars", "Falcons", "Hornets", "Giants", "Icemen"}},
+      {14, 9.0, []string{"Hornets", "Bandits", "Jaguars", "Aces", "Dragons", "Icemen", "Falcons", "Cobras", "Eagles", "Giants"}},
+   }
+
+   expectedIneligibleOwners := map[int][]string{
+      2: {"Aces", "Bandits", "Cobras", "Eagles", "Falcons", "Icemen"},
+      5: {"Dragons", "Eagles", "Giants", "Hornets", "Icemen", "Jaguars"},
+      6: {"Aces", "Bandits", "Cobras", "Giants", "Hornets", "Jaguars"},
+      7: {"Aces", "Bandits", "Dragons", "Eagles", "Falcons", "Giants"},
+   }
+
+   prizeSchedule := makeDefaultTestSchedule(pTest)
+   _, seasonData := newTestSeason(pTest, prizeSchedule)
+
+   for idx, expectedSummary := range expectedSummaries {
+
+      summary := GetWeekSummary(prizeSchedule[idx].mPrize, seasonData, expectedSummary.week)
+
+      if summary.Err != nil {
+         pTest.Errorf("Week %d: Unexpected error: %s", expectedSummary.week, summary.Err.Error())
+         continue
+      }
+
+      if owners := getOwners(summary.PrizeEntries) ; !slices.Equal(owners, expectedSummary.owners) {
+         pTest.Errorf("Week %d: Owners %v, expected %v", expectedSummary.week, owners, expectedSummary.owners)
+      }
+
+      if owners := getOwners(summary.IneligibleEntries) ; !slices.Equal(owners, expectedIneligibleOwners[expectedSummary.week]) {
+         pTest.Errorf("Week %d: Ineligible owners %v, expected %v", expectedSummary.week, owners, expectedIneligibleOwners[expectedSummary.week])
+      }
+
+      if winningScore := summary.PrizeEntries[0].Score ; math.Abs(winningScore - expectedSummary.winningScore) > 1e-3 {
+         pTest.Errorf("Week %d: Winning score %f, expected %f", expectedSummary.week, winningScore, expectedSummary.winningScore)
+      }
+   }
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func TestMissingWeekDataOnlyFailsThatWeek(pTest *testing.T) {
+
+   hotStart, _ := GetPrize("Hot Start")
+   touchdownDance, _ := GetPrize("Touchdown Dance")
+
+   // The fixtures have no week 13 stats
+   prizeSchedule := []ScheduledPrize{{1, hotStart}, {13, touchdownDance}}
+   _, seasonData := newTestSeason(pTest, prizeSchedule)
+
+   if summary := GetWeekSummary(hotStart, seasonData, 1) ; summary.Err != nil {
+      pTest.Errorf("Week 1: Unexpected error: %s", summary.Err.Error())
+   }
+
+   if summary := GetWeekSummary(touchdownDance, seasonData, 13) ; !errors.Is(summary.Err, ErrNotFound) {
+      pTest.Errorf("Week 13: Error %v, expected ErrNotFound", summary.Err)
+   }
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+//--------------------------------------------------------------------------------------------------
+// WeekGameTimes holds the league's lineup lock for a week and the kickoff time of each NFL team's
+// game, keyed by team abbreviation.
+//--------------------------------------------------------------------------------------------------
+type WeekGameTimes struct {
+   LineupLock time.Time
+   Kickoffs map[string]time.Time
+}
+
+//--------------------------------------------------------------------------------------------------
+// LoadGameTimes reads a file mapping week numbers to their WeekGameTimes.
+//--------------------------------------------------------------------------------------------------
+func LoadGameTimes(pFilePath string) (map[int]WeekGameTimes, error) {
+
+   gameTimesData, err := os.ReadFile(pFilePath)
+
+   if err != nil {
+      return nil, err
+   }
+
+   var weekGameTimes map[string]WeekGameTimes
+   err = json.Unmarshal(gameTimesData, &weekGameTimes)
+
+   if err != nil {
+      return nil, fmt.Errorf("LoadGameTimes: Failed to decode %s: %w", pFilePath, err)
+   }
+
+   gameTimes := make(map[int]WeekGameTimes)
+
+   for weekKey, curWeekGameTimes := range weekGameTimes {
+      week, err := strconv.Atoi(weekKey)
+
+      if err != nil {
+         return nil, fmt.Errorf("LoadGameTimes: Invalid week %q in %s", weekKey, pFilePath)
+      }
+
+      gameTimes[week] = curWeekGameTimes
+   }
+
+   return gameTimes, nil
+}
+
+//--------------------------------------------------------------------------------------------------
+// IsLocked reports whether the team's game had kicked off by the lineup lock. Teams without a known
+// kickoff, and every team in a week without a lineup lock, are never locked.
+//--------------------------------------------------------------------------------------------------
+func (weekGameTimes WeekGameTimes) IsLocked(pTeam string) bool {
+
+   if weekGameTimes.LineupLock.IsZero() {
+      return false
+   }
+
+   kickoff, hasKickoff := weekGameTimes.Kickoffs[pTeam]
+
+   return hasKickoff && !kickoff.After(weekGameTimes.LineupLock)
+}
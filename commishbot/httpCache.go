@@ -0,0 +1,153 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Entries fetched with CacheForever never expire and are only refetched in CacheModeRefresh. An entry
+// cached with a ttl, such as the matchups of a week still being played, is revalidated once its data
+// is requested with CacheForever.
+const CacheForever time.Duration = -1
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type CacheMode int
+
+const (
+   CacheModeNormal CacheMode = iota
+   CacheModeRefresh
+   CacheModeOffline
+)
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type HttpCache struct {
+   mDir string
+   mMode CacheMode
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type HttpCacheEntry struct {
+   Url string
+   FetchedAt time.Time
+   ETag string
+   LastModified string
+   Body string
+   Permanent bool
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func NewHttpCache(pDir string, pMode CacheMode) *HttpCache {
+   return &HttpCache{pDir, pMode}
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (cache *HttpCache) Mode() CacheMode {
+   return cache.mMode
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (cache *HttpCache) Load(pUrl string) (HttpCacheEntry, bool) {
+
+   entryBytes, err := os.ReadFile(cache.getEntryPath(pUrl))
+
+   if err != nil {
+      return HttpCacheEntry{}, false
+   }
+
+   var entry HttpCacheEntry
+   err = json.Unmarshal(entryBytes, &entry)
+
+   if err != nil || entry.Url != pUrl {
+      return HttpCacheEntry{}, false
+   }
+
+   return entry, true
+}
+
+//--------------------------------------------------------------------------------------------------
+// Entries are written atomically so that concurrent requests for the same url never observe a
+// partially written entry.
+//--------------------------------------------------------------------------------------------------
+func (cache *HttpCache) Store(pEntry HttpCacheEntry) error {
+
+   entryBytes, err := json.Marshal(pEntry)
+
+   if err != nil {
+      return err
+   }
+
+   return writeFileAtomically(cache.getEntryPath(pEntry.Url), entryBytes)
+}
+
+//--------------------------------------------------------------------------------------------------
+// writeFileAtomically writes pData to a temporary file next to pFilePath and renames it into place,
+// creating the directory if needed, so that readers never observe a partially written file.
+//--------------------------------------------------------------------------------------------------
+func writeFileAtomically(pFilePath string, pData []byte) error {
+
+   dir := filepath.Dir(pFilePath)
+   err := os.MkdirAll(dir, 0755)
+
+   if err != nil {
+      return err
+   }
+
+   tempFile, err := os.CreateTemp(dir, filepath.Base(pFilePath) + "-*.tmp")
+
+   if err != nil {
+      return err
+   }
+
+   _, err = tempFile.Write(pData)
+   closeErr := tempFile.Close()
+
+   if err == nil {
+      err = closeErr
+   }
+
+   if err == nil {
+      err = os.Rename(tempFile.Name(), pFilePath)
+   }
+
+   if err != nil {
+      os.Remove(tempFile.Name())
+   }
+
+   return err
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (entry HttpCacheEntry) IsFresh(pTtl time.Duration, pNow time.Time) bool {
+
+   if pTtl == CacheForever {
+      return entry.Permanent
+   }
+
+   return pNow.Sub(entry.FetchedAt) < pTtl
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (cache *HttpCache) getEntryPath(pUrl string) string {
+   urlHash := sha256.Sum256([]byte(pUrl))
+   return filepath.Join(cache.mDir, hex.EncodeToString(urlHash[:]) + ".json")
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+//--------------------------------------------------------------------------------------------------
+// Week 1 is over in the fixture NFL state, so its matchups are cached forever, but only once they have
+// been fetched after the week ended. A response cached while its games were being played is refetched.
+//--------------------------------------------------------------------------------------------------
+func TestMidWeekCacheEntryIsRefetched(pTest *testing.T) {
+
+   server, client := newTestClient(pTest)
+   cache := NewHttpCache(pTest.TempDir(), CacheModeNormal)
+   client.SetCache(cache)
+
+   matchupsPath := "/league/" + testLeagueId + "/matchups/1"
+   err := cache.Store(HttpCacheEntry{Url: server.BaseUrl() + matchupsPath, FetchedAt: time.Now(), Body: "[]"})
+
+   if err != nil {
+      pTest.Fatalf("Store: %s", err.Error())
+   }
+
+   for idx := 0 ; idx < 2 ; idx++ {
+      matchups, err := client.GetMatchups(context.Background(), testLeagueId, 1)
+
+      if err != nil {
+         pTest.Fatalf("GetMatchups: %s", err.Error())
+      }
+
+      if len(matchups) != 10 {
+         pTest.Errorf("Got %d matchups, expected the 10 final ones instead of the mid-week entry", len(matchups))
+      }
+   }
+
+   if requestCount := server.RequestCount("/v1" + matchupsPath) ; requestCount != 1 {
+      pTest.Errorf("Week 1 matchups were requested %d times, expected a single refetch", requestCount)
+   }
+}
@@ -1,8 +1,9 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
+	"fmt"
 	"strconv"
 )
 
@@ -20,49 +21,75 @@ type League struct {
    mRosterPositionCounts map[string]int
 
    Scoring_settings map[string]json.RawMessage
+   Settings LeagueSettings
+   Metadata map[string]string
 }
 
 //--------------------------------------------------------------------------------------------------
 //
 //--------------------------------------------------------------------------------------------------
-func GetUserLeaguesData(pUserId string, pYear int) string {
-   return GetHttpResponse("https://api.sleeper.app/v1/user/" + pUserId + "/leagues/nfl/" + strconv.Itoa(pYear))
+type LeagueSettings struct {
+   Playoff_week_start int
+   Playoff_teams int
+   Divisions int
+   League_average_match int
 }
 
 //--------------------------------------------------------------------------------------------------
 //
 //--------------------------------------------------------------------------------------------------
-func GetUserLeagues(pUserId string, pYear int) []League {
-   userLeagueData := GetUserLeaguesData(pUserId, pYear)
+func (client *SleeperClient) GetUserLeaguesData(pContext context.Context, pUserId string, pYear int) (string, error) {
+   return client.getHttpResponse(pContext, client.mBaseUrl + "/user/" + pUserId + "/leagues/nfl/" + strconv.Itoa(pYear), defaultCacheTtl)
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (client *SleeperClient) GetUserLeagues(pContext context.Context, pUserId string, pYear int) ([]League, error) {
+   userLeagueData, err := client.GetUserLeaguesData(pContext, pUserId, pYear)
+
+   if err != nil {
+      return nil, err
+   }
 
    var leagues []League
-   err := json.Unmarshal([]byte(userLeagueData), &leagues)
-   check(err)
+   err = unmarshalSleeperData(userLeagueData, "user " + pUserId + " leagues", &leagues)
+
+   if err != nil {
+      return nil, err
+   }
 
-   return leagues
+   return leagues, nil
 }
 
 //--------------------------------------------------------------------------------------------------
 //
 //--------------------------------------------------------------------------------------------------
-func GetLeagueData(pLeagueId string) string {
-   return GetHttpResponse("https://api.sleeper.app/v1/league/" + pLeagueId)
+func (client *SleeperClient) GetLeagueData(pContext context.Context, pLeagueId string) (string, error) {
+   return client.getHttpResponse(pContext, client.mBaseUrl + "/league/" + pLeagueId, defaultCacheTtl)
 }
 
 //--------------------------------------------------------------------------------------------------
 //
 //--------------------------------------------------------------------------------------------------
-func GetLeague(pLeagueId string) League {
+func (client *SleeperClient) GetLeague(pContext context.Context, pLeagueId string) (League, error) {
 
-   leagueData := GetLeagueData(pLeagueId)
+   leagueData, err := client.GetLeagueData(pContext, pLeagueId)
+
+   if err != nil {
+      return League{}, err
+   }
 
    var league League
-   err := json.Unmarshal([]byte(leagueData), &league)
-   check(err)
+   err = unmarshalSleeperData(leagueData, "league " + pLeagueId, &league)
+
+   if err != nil {
+      return League{}, err
+   }
 
    league.populateRosterPositionCounts()
 
-   return league
+   return league, nil
 }
 
 //--------------------------------------------------------------------------------------------------
@@ -76,6 +103,44 @@ func (league *League) populateRosterPositionCounts() {
    }
 }
 
+//--------------------------------------------------------------------------------------------------
+// Leagues name their divisions in the metadata, falling back to the division number.
+//--------------------------------------------------------------------------------------------------
+func (league League) GetDivisionName(pDivision int) string {
+
+   if divisionName := league.Metadata["division_" + strconv.Itoa(pDivision)] ; divisionName != "" {
+      return divisionName
+   }
+
+   return "Division " + strconv.Itoa(pDivision)
+}
+
+//--------------------------------------------------------------------------------------------------
+// Playoff rounds are assumed to last one week each. Weeks outside the playoffs are round 0.
+//--------------------------------------------------------------------------------------------------
+func (league League) GetPlayoffRound(pWeek int) int {
+
+   if league.Settings.Playoff_week_start <= 0 || pWeek < league.Settings.Playoff_week_start {
+      return 0
+   }
+
+   return pWeek - league.Settings.Playoff_week_start + 1
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (league League) GetPlayoffRoundWeek(pRound int) int {
+   return league.Settings.Playoff_week_start + pRound - 1
+}
+
+//--------------------------------------------------------------------------------------------------
+// Median leagues also score each team against the league median every week.
+//--------------------------------------------------------------------------------------------------
+func (league League) HasMedianMatchup() bool {
+   return league.Settings.League_average_match != 0
+}
+
 //--------------------------------------------------------------------------------------------------
 //
 //--------------------------------------------------------------------------------------------------
@@ -107,14 +172,14 @@ func GetScoringValue(pScoringSettings map[string]json.RawMessage, pScoringKey st
          return exceptionValue, nil
       }
 
-      return 0.0, errors.New("Failed to retrieve " + pScoringKey + " score setting")
+      return 0.0, fmt.Errorf("Failed to retrieve %s score setting: %w", pScoringKey, ErrNotFound)
    }
 
    var scoringValue float64
    err := json.Unmarshal([]byte(scoringValueData), &scoringValue)
 
    if err != nil {
-      return 0.0, errors.New("Failed to unmarshal " + pScoringKey + " score setting")
+      return 0.0, fmt.Errorf("Failed to unmarshal %s score setting: %w", pScoringKey, ErrDecode)
    }
 
    return scoringValue, nil
@@ -1,5 +1,7 @@
 package main
 
+import "context"
+
 //--------------------------------------------------------------------------------------------------
 //
 //--------------------------------------------------------------------------------------------------
@@ -13,14 +15,84 @@ type LeagueInfo struct {
 //--------------------------------------------------------------------------------------------------
 //
 //--------------------------------------------------------------------------------------------------
-func GetLeagueInfo(pLeagueId string) LeagueInfo {
+func GetLeagueInfo(pContext context.Context, pClient *SleeperClient, pLeagueId string) (LeagueInfo, error) {
    var leagueInfo LeagueInfo
 
-   leagueInfo.mLeague = GetLeague(pLeagueId)
-   leagueInfo.mLeagueUsers = GetLeagueUsers(pLeagueId)
-   leagueInfo.mRosters = GetRosters(pLeagueId)
+   group, _ := NewTaskGroup(pContext, 3)
+   leagueInfo.addLoadTasks(group, pClient, pLeagueId)
+
+   err := group.Wait()
+
+   if err != nil {
+      return LeagueInfo{}, err
+   }
 
    leagueInfo.mDisplayNames = MakeDisplayNamesMap(leagueInfo.mLeagueUsers)
 
-   return leagueInfo
+   return leagueInfo, nil
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (leagueInfo *LeagueInfo) addLoadTasks(pGroup *TaskGroup, pClient *SleeperClient, pLeagueId string) {
+
+   pGroup.Go(func(pContext context.Context) error {
+      var err error
+      leagueInfo.mLeague, err = pClient.GetLeague(pContext, pLeagueId)
+      return err
+   })
+
+   pGroup.Go(func(pContext context.Context) error {
+      var err error
+      leagueInfo.mLeagueUsers, err = pClient.GetLeagueUsers(pContext, pLeagueId)
+      return err
+   })
+
+   pGroup.Go(func(pContext context.Context) error {
+      var err error
+      leagueInfo.mRosters, err = pClient.GetRosters(pContext, pLeagueId)
+      return err
+   })
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (leagueInfo LeagueInfo) GetRoster(pRosterId int) (Roster, bool) {
+
+   for _, roster := range leagueInfo.mRosters {
+      if roster.Roster_id == pRosterId {
+         return roster, true
+      }
+   }
+
+   return Roster{}, false
+}
+
+//--------------------------------------------------------------------------------------------------
+// GetOwnerRosterId returns the Roster_id of the owner with the display name pOwner, or 0 if there is
+// none.
+//--------------------------------------------------------------------------------------------------
+func (leagueInfo LeagueInfo) GetOwnerRosterId(pOwner string) int {
+
+   for _, roster := range leagueInfo.mRosters {
+      if roster.Owner_id != "" && leagueInfo.mDisplayNames[roster.Owner_id] == pOwner {
+         return roster.Roster_id
+      }
+   }
+
+   return 0
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (leagueInfo LeagueInfo) GetOwnerName(pRosterId int) string {
+
+   if roster, hasRoster := leagueInfo.GetRoster(pRosterId) ; hasRoster {
+      return leagueInfo.mDisplayNames[roster.Owner_id]
+   }
+
+   return ""
 }
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+)
+
+var ErrNoLeagueSelected = errors.New("no league selected")
+
+//--------------------------------------------------------------------------------------------------
+// SelectLeagues picks the leagues named by pSelectors, each either a League_id or a league name, which
+// is matched ignoring case. Without selectors a user in a single league gets that league, while a
+// user in several has to choose.
+//--------------------------------------------------------------------------------------------------
+func SelectLeagues(pLeagues []League, pSelectors []string) ([]League, error) {
+
+   if len(pSelectors) == 0 {
+      if len(pLeagues) == 1 {
+         return pLeagues, nil
+      }
+
+      if len(pLeagues) == 0 {
+         return nil, fmt.Errorf("SelectLeagues: The user has no leagues: %w", ErrNotFound)
+      }
+
+      return nil, fmt.Errorf("SelectLeagues: The user is in %d leagues, choose one with --league or Leagues in the config: %w", len(pLeagues), ErrNoLeagueSelected)
+   }
+
+   var selectedLeagues []League
+   var errs []error
+   selectedIds := make(map[string]bool)
+
+   for _, selector := range pSelectors {
+
+      league, err := selectLeague(pLeagues, selector)
+
+      if err != nil {
+         errs = append(errs, err)
+         continue
+      }
+
+      if !selectedIds[league.League_id] {
+         selectedIds[league.League_id] = true
+         selectedLeagues = append(selectedLeagues, league)
+      }
+   }
+
+   if len(errs) > 0 {
+      return nil, errors.Join(errs...)
+   }
+
+   return selectedLeagues, nil
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func selectLeague(pLeagues []League, pSelector string) (League, error) {
+
+   selector := strings.TrimSpace(pSelector)
+
+   for _, league := range pLeagues {
+      if league.League_id == selector {
+         return league, nil
+      }
+   }
+
+   var namedLeagues []League
+
+   for _, league := range pLeagues {
+      if strings.EqualFold(league.Name, selector) {
+         namedLeagues = append(namedLeagues, league)
+      }
+   }
+
+   if len(namedLeagues) > 1 {
+      return League{}, fmt.Errorf("selectLeague: %d leagues are named %q, choose one by League_id", len(namedLeagues), selector)
+   }
+
+   if len(namedLeagues) == 0 {
+      return League{}, fmt.Errorf("selectLeague: The user has no league with the Id or name %q: %w", selector, ErrNotFound)
+   }
+
+   return namedLeagues[0], nil
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func printLeagues(pUsername string, pYear int, pLeagues []League) {
+   log.Printf("%s's %d Leagues", pUsername, pYear)
+
+   for _, league := range pLeagues {
+      log.Printf("   %s: %s (%d Teams)", league.League_id, league.Name, league.Total_rosters)
+   }
+}
+
+//--------------------------------------------------------------------------------------------------
+// getLeagueFilePath keeps the files of several leagues apart by adding the League_id to the name,
+// turning Ledger.json into Ledger.<League_id>.json.
+//--------------------------------------------------------------------------------------------------
+func getLeagueFilePath(pFilePath string, pLeagueId string) string {
+   extension := filepath.Ext(pFilePath)
+
+   return strings.TrimSuffix(pFilePath, extension) + "." + pLeagueId + extension
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func getLeagueIds(pLeagues []League) []string {
+   var leagueIds []string
+
+   for _, league := range pLeagues {
+      leagueIds = append(leagueIds, league.League_id)
+   }
+
+   return leagueIds
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func TestSelectLeagues(pTest *testing.T) {
+
+   leagues := []League{
+      {Name: "Work League", League_id: "1000"},
+      {Name: "Family League", League_id: "2000"},
+      {Name: "Dynasty", League_id: "3000"},
+      {Name: "dynasty", League_id: "4000"},
+   }
+
+   testCases := []struct {
+      selectors []string
+      expectedIds []string
+      expectedErr error
+   }{
+      {nil, nil, ErrNoLeagueSelected},
+      {[]string{"2000"}, []string{"2000"}, nil},
+      {[]string{"family league"}, []string{"2000"}, nil},
+      {[]string{" Work League ", "3000", "1000"}, []string{"1000", "3000"}, nil},
+      {[]string{"Office League"}, nil, ErrNotFound},
+      {[]string{"Dynasty"}, nil, nil},
+   }
+
+   for _, testCase := range testCases {
+
+      selectedLeagues, err := SelectLeagues(leagues, testCase.selectors)
+      selectedIds := getLeagueIds(selectedLeagues)
+
+      if testCase.expectedIds == nil && err == nil {
+         pTest.Errorf("Selectors %q gave %v, expected an error", testCase.selectors, selectedIds)
+      }
+
+      if testCase.expectedErr != nil && !errors.Is(err, testCase.expectedErr) {
+         pTest.Errorf("Selectors %q gave error %v, expected %v", testCase.selectors, err, testCase.expectedErr)
+      }
+
+      if testCase.expectedIds != nil && (err != nil || len(selectedIds) != len(testCase.expectedIds)) {
+         pTest.Errorf("Selectors %q gave %v (%v), expected %v", testCase.selectors, selectedIds, err, testCase.expectedIds)
+         continue
+      }
+
+      for idx := 0 ; idx < len(testCase.expectedIds) ; idx++ {
+         if selectedIds[idx] != testCase.expectedIds[idx] {
+            pTest.Errorf("Selectors %q gave %v, expected %v", testCase.selectors, selectedIds, testCase.expectedIds)
+            break
+         }
+      }
+   }
+
+   if selectedLeagues, err := SelectLeagues(leagues[:1], nil) ; err != nil || len(selectedLeagues) != 1 {
+      pTest.Errorf("A single league gave %v (%v), expected it to be selected", getLeagueIds(selectedLeagues), err)
+   }
+
+   if filePath := getLeagueFilePath("data/Ledger.json", "1000") ; filePath != "data/Ledger.1000.json" {
+      pTest.Errorf("League file path %s, expected data/Ledger.1000.json", filePath)
+   }
+}
@@ -1,6 +1,6 @@
 package main
 
-import "encoding/json"
+import "context"
 
 //--------------------------------------------------------------------------------------------------
 //
@@ -13,22 +13,29 @@ type LeagueUser struct {
 //--------------------------------------------------------------------------------------------------
 //
 //--------------------------------------------------------------------------------------------------
-func GetLeagueUsersData(pLeagueId string) string {
-   return GetHttpResponse("https://api.sleeper.app/v1/league/" + pLeagueId + "/users")
+func (client *SleeperClient) GetLeagueUsersData(pContext context.Context, pLeagueId string) (string, error) {
+   return client.getHttpResponse(pContext, client.mBaseUrl + "/league/" + pLeagueId + "/users", defaultCacheTtl)
 }
 
 //--------------------------------------------------------------------------------------------------
 //
 //--------------------------------------------------------------------------------------------------
-func GetLeagueUsers(pLeagueId string) []LeagueUser {
+func (client *SleeperClient) GetLeagueUsers(pContext context.Context, pLeagueId string) ([]LeagueUser, error) {
 
-   leagueUsersData := GetLeagueUsersData(pLeagueId)
+   leagueUsersData, err := client.GetLeagueUsersData(pContext, pLeagueId)
+
+   if err != nil {
+      return nil, err
+   }
 
    var leagueUsers []LeagueUser
-   err := json.Unmarshal([]byte(leagueUsersData), &leagueUsers)
-   check(err)
+   err = unmarshalSleeperData(leagueUsersData, "league " + pLeagueId + " users", &leagueUsers)
+
+   if err != nil {
+      return nil, err
+   }
 
-   return leagueUsers
+   return leagueUsers, nil
 }
 
 //--------------------------------------------------------------------------------------------------
@@ -0,0 +1,335 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+)
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type LedgerEntryType string
+
+const (
+   LedgerDues LedgerEntryType = "Dues"
+   LedgerWeeklyPrize LedgerEntryType = "Weekly Prize"
+   LedgerFinalStandings LedgerEntryType = "Final Standings"
+)
+
+//--------------------------------------------------------------------------------------------------
+// Dues are money an owner paid in and every other entry is a payout to the owner. Entries are keyed
+// by Id so that posting the same result on every run only records it once. Ids are built from the
+// Roster_id, since owners can change their display name, and Owner is only kept for printing.
+//--------------------------------------------------------------------------------------------------
+type LedgerEntry struct {
+   Id string
+   Type LedgerEntryType
+   Roster_id int
+   Owner string
+   Week int
+   Description string
+   Amount float64
+   PostedAt time.Time
+   Sent bool
+   SentAt time.Time
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type Ledger struct {
+   Entries []LedgerEntry
+
+   mFilePath string
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type OwnerStatement struct {
+   Roster_id int
+   Owner string
+   PaidIn float64
+   Won float64
+   Net float64
+   Unsent float64
+}
+
+//--------------------------------------------------------------------------------------------------
+// LoadLedger reads the ledger saved at pFilePath, starting an empty one if the file does not exist.
+//--------------------------------------------------------------------------------------------------
+func LoadLedger(pFilePath string) (Ledger, error) {
+
+   var ledger Ledger
+   ledger.mFilePath = pFilePath
+
+   ledgerBytes, err := os.ReadFile(pFilePath)
+
+   if errors.Is(err, fs.ErrNotExist) {
+      return ledger, nil
+   }
+
+   if err != nil {
+      return Ledger{}, err
+   }
+
+   err = json.Unmarshal(ledgerBytes, &ledger)
+
+   if err != nil {
+      return Ledger{}, fmt.Errorf("LoadLedger: Failed to decode %s: %w", pFilePath, err)
+   }
+
+   return ledger, nil
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (ledger Ledger) Save() error {
+
+   ledgerBytes, err := json.MarshalIndent(ledger, "", "   ")
+
+   if err != nil {
+      return err
+   }
+
+   return writeFileAtomically(ledger.mFilePath, ledgerBytes)
+}
+
+//--------------------------------------------------------------------------------------------------
+// Post records pEntry unless an entry with the same Id was already posted, and reports whether it
+// was added.
+//--------------------------------------------------------------------------------------------------
+func (ledger *Ledger) Post(pEntry LedgerEntry) bool {
+
+   if _, hasEntry := ledger.findEntry(pEntry.Id) ; hasEntry {
+      return false
+   }
+
+   if pEntry.PostedAt.IsZero() {
+      pEntry.PostedAt = time.Now()
+   }
+
+   ledger.Entries = append(ledger.Entries, pEntry)
+
+   return true
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (ledger *Ledger) MarkSent(pId string) error {
+
+   entryIdx, hasEntry := ledger.findEntry(pId)
+
+   if !hasEntry {
+      return fmt.Errorf("MarkSent: Ledger entry %q: %w", pId, ErrNotFound)
+   }
+
+   entry := &ledger.Entries[entryIdx]
+
+   if entry.Type == LedgerDues {
+      return fmt.Errorf("MarkSent: Ledger entry %q is dues, not a payout", pId)
+   }
+
+   if !entry.Sent {
+      entry.Sent = true
+      entry.SentAt = time.Now()
+   }
+
+   return nil
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (ledger Ledger) findEntry(pId string) (int, bool) {
+
+   for idx, entry := range ledger.Entries {
+      if entry.Id == pId {
+         return idx, true
+      }
+   }
+
+   return 0, false
+}
+
+//--------------------------------------------------------------------------------------------------
+// UpdateOwnerNames prints every entry under its roster's current display name.
+//--------------------------------------------------------------------------------------------------
+func (ledger *Ledger) UpdateOwnerNames(pLeagueInfo LeagueInfo) {
+   for idx := range ledger.Entries {
+      if owner := pLeagueInfo.GetOwnerName(ledger.Entries[idx].Roster_id) ; owner != "" {
+         ledger.Entries[idx].Owner = owner
+      }
+   }
+}
+
+//--------------------------------------------------------------------------------------------------
+// PostDues records the dues of every owner in the league. Rosters without an owner pay no dues.
+//--------------------------------------------------------------------------------------------------
+func (ledger *Ledger) PostDues(pLeagueInfo LeagueInfo, pDues float64) {
+
+   if pDues <= 0.0 {
+      return
+   }
+
+   for _, roster := range pLeagueInfo.mRosters {
+      if roster.Owner_id != "" {
+         ledger.Post(LedgerEntry{Id: "dues-roster-" + strconv.Itoa(roster.Roster_id), Type: LedgerDues, Roster_id: roster.Roster_id, Owner: pLeagueInfo.GetOwnerName(roster.Roster_id), Description: "League Dues", Amount: pDues})
+      }
+   }
+}
+
+//--------------------------------------------------------------------------------------------------
+// PostWeekSummaries pays out the prize of every finalized summary that has a winner, together with
+// the payouts of the weeks rolled over to it. Tied winners split the payout. Winners stored before
+// prize entries carried their Roster_id are looked up by owner name.
+//--------------------------------------------------------------------------------------------------
+func (ledger *Ledger) PostWeekSummaries(pLeagueInfo LeagueInfo, pSummaries []WeekSummary, pWeekPayouts map[int]float64) {
+
+   for _, summary := range pSummaries {
+
+      if !summary.Finalized || !summary.HasWinner() {
+         continue
+      }
+
+      payout := pWeekPayouts[summary.Week]
+      description := "Week " + strconv.Itoa(summary.Week) + " " + summary.Criteria
+
+      for _, rolledOverWeek := range summary.RolledOverWeeks {
+         payout += pWeekPayouts[rolledOverWeek]
+      }
+
+      if len(summary.RolledOverWeeks) > 0 {
+         description += " (Includes Weeks " + formatWeeks(summary.RolledOverWeeks) + ")"
+      }
+
+      if payout <= 0.0 {
+         continue
+      }
+
+      winners := summary.PrizeEntries.Winners()
+
+      for _, winner := range winners {
+
+         rosterId := winner.Roster_id
+
+         if rosterId == 0 {
+            rosterId = pLeagueInfo.GetOwnerRosterId(winner.Owner)
+         }
+
+         ledger.Post(LedgerEntry{
+            Id: "week-" + strconv.Itoa(summary.Week) + "-roster-" + strconv.Itoa(rosterId),
+            Type: LedgerWeeklyPrize,
+            Roster_id: rosterId,
+            Owner: winner.Owner,
+            Week: summary.Week,
+            Description: description,
+            Amount: payout / float64(len(winners)),
+         })
+      }
+   }
+}
+
+//--------------------------------------------------------------------------------------------------
+// PostFinalStandings pays out the places decided in the winners bracket, pPayouts[0] going to the
+// champion.
+//--------------------------------------------------------------------------------------------------
+func (ledger *Ledger) PostFinalStandings(pLeagueInfo LeagueInfo, pWinnersBracket Bracket, pPayouts []float64) {
+
+   for rosterId, place := range pWinnersBracket.GetFinalPlaces() {
+
+      if place > len(pPayouts) || pPayouts[place-1] <= 0.0 {
+         continue
+      }
+
+      ledger.Post(LedgerEntry{Id: "final-" + strconv.Itoa(place), Type: LedgerFinalStandings, Roster_id: rosterId, Owner: pLeagueInfo.GetOwnerName(rosterId), Description: getOrdinal(place) + " Place", Amount: pPayouts[place-1]})
+   }
+}
+
+//--------------------------------------------------------------------------------------------------
+// GetStatements totals the ledger by roster, in owner order. A statement is printed under the owner
+// name of the roster's latest entry.
+//--------------------------------------------------------------------------------------------------
+func (ledger Ledger) GetStatements() []OwnerStatement {
+
+   statements := make(map[int]*OwnerStatement)
+
+   for _, entry := range ledger.Entries {
+
+      statement, hasRoster := statements[entry.Roster_id]
+
+      if !hasRoster {
+         statement = &OwnerStatement{Roster_id: entry.Roster_id}
+         statements[entry.Roster_id] = statement
+      }
+
+      statement.Owner = entry.Owner
+
+      if entry.Type == LedgerDues {
+         statement.PaidIn += entry.Amount
+         continue
+      }
+
+      statement.Won += entry.Amount
+
+      if !entry.Sent {
+         statement.Unsent += entry.Amount
+      }
+   }
+
+   var ownerStatements []OwnerStatement
+
+   for _, statement := range statements {
+      statement.Net = statement.Won - statement.PaidIn
+      ownerStatements = append(ownerStatements, *statement)
+   }
+
+   sort.Slice(ownerStatements, func(i, j int) bool {
+      if ownerStatements[i].Owner != ownerStatements[j].Owner {
+         return ownerStatements[i].Owner < ownerStatements[j].Owner
+      }
+
+      return ownerStatements[i].Roster_id < ownerStatements[j].Roster_id
+   })
+
+   return ownerStatements
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (ledger Ledger) Print() {
+
+   log.Printf("Prize Ledger")
+   log.Printf("   %-16s %10s %10s %10s %10s", "Owner", "Paid In", "Won", "Net", "Unsent")
+
+   for _, statement := range ledger.GetStatements() {
+      log.Printf("   %-16s %10.2f %10.2f %10.2f %10.2f", statement.Owner, statement.PaidIn, statement.Won, statement.Net, statement.Unsent)
+   }
+
+   log.Printf("   Payouts:")
+
+   for _, entry := range ledger.Entries {
+
+      if entry.Type == LedgerDues {
+         continue
+      }
+
+      status := "Not Sent"
+
+      if entry.Sent {
+         status = "Sent " + entry.SentAt.Format(time.DateOnly)
+      }
+
+      log.Printf("      %s: %s %.2f, %s (%s)", entry.Id, entry.Owner, entry.Amount, entry.Description, status)
+   }
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"path/filepath"
+	"testing"
+)
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func TestLedger(pTest *testing.T) {
+
+   leagueInfo := LeagueInfo{
+      mDisplayNames: map[string]string{"u1": "Aces", "u2": "Bandits"},
+      mRosters: []Roster{{Owner_id: "u1", Roster_id: 1}, {Owner_id: "u2", Roster_id: 2}, {Roster_id: 3}},
+   }
+
+   summaries := []WeekSummary{
+      {Week: 1, Criteria: "Hot Start", Finalized: true, PrizeEntries: PrizeEntries{{Owner: "Aces", Roster_id: 1, Rank: 1}, {Owner: "Bandits", Roster_id: 2, Rank: 2}}},
+      {Week: 2, Criteria: "MVP", Finalized: true},
+      {Week: 3, Criteria: "Blackjack", Finalized: true, PrizeEntries: PrizeEntries{{Owner: "Aces", Roster_id: 1, Rank: 1}, {Owner: "Bandits", Rank: 1}}, RolledOverWeeks: []int{2}},
+      {Week: 4, Criteria: "Photo Finish", PrizeEntries: PrizeEntries{{Owner: "Bandits", Roster_id: 2, Rank: 1}}},
+   }
+
+   weekPayouts := map[int]float64{1: 20.0, 2: 20.0, 3: 30.0, 4: 20.0}
+   filePath := filepath.Join(pTest.TempDir(), "Ledger.json")
+
+   ledger, err := LoadLedger(filePath)
+
+   if err != nil {
+      pTest.Fatalf("LoadLedger: %s", err.Error())
+   }
+
+   // Week 4 is not finalized, the orphaned roster 3 pays no dues, and posting the same weeks again
+   // changes nothing
+   ledger.PostWeekSummaries(leagueInfo, summaries, weekPayouts)
+   ledger.PostDues(leagueInfo, 100.0)
+
+   // Renamed owners keep their entries
+   leagueInfo.mDisplayNames = map[string]string{"u1": "Aces High", "u2": "Bandits"}
+   summaries[0].PrizeEntries[0].Owner = "Aces High"
+
+   ledger.PostWeekSummaries(leagueInfo, summaries, weekPayouts)
+   ledger.PostDues(leagueInfo, 100.0)
+   ledger.UpdateOwnerNames(leagueInfo)
+
+   if len(ledger.Entries) != 5 {
+      pTest.Fatalf("%d entries %+v, expected 5", len(ledger.Entries), ledger.Entries)
+   }
+
+   err = ledger.MarkSent("week-1-roster-1")
+
+   if err == nil {
+      err = ledger.Save()
+   }
+
+   if err != nil {
+      pTest.Fatalf("Failed to mark and save the ledger: %s", err.Error())
+   }
+
+   if err = ledger.MarkSent("week-4-roster-2") ; !errors.Is(err, ErrNotFound) {
+      pTest.Errorf("Marking an unposted payout as sent gave %v, expected ErrNotFound", err)
+   }
+
+   if err = ledger.MarkSent("dues-roster-1") ; err == nil {
+      pTest.Errorf("Marking dues as sent succeeded, expected an error")
+   }
+
+   ledger, err = LoadLedger(filePath)
+
+   if err != nil {
+      pTest.Fatalf("LoadLedger: %s", err.Error())
+   }
+
+   // The tied week 3 winners split its payout and the one rolled over from week 2
+   expectedStatements := []OwnerStatement{
+      {Roster_id: 1, Owner: "Aces High", PaidIn: 100.0, Won: 45.0, Net: -55.0, Unsent: 25.0},
+      {Roster_id: 2, Owner: "Bandits", PaidIn: 100.0, Won: 25.0, Net: -75.0, Unsent: 25.0},
+   }
+
+   statements := ledger.GetStatements()
+
+   if len(statements) != len(expectedStatements) {
+      pTest.Fatalf("Statements %+v, expected %+v", statements, expectedStatements)
+   }
+
+   for idx, expectedStatement := range expectedStatements {
+
+      statement := statements[idx]
+      amounts := []float64{statement.PaidIn - expectedStatement.PaidIn, statement.Won - expectedStatement.Won, statement.Net - expectedStatement.Net, statement.Unsent - expectedStatement.Unsent}
+
+      for _, amount := range amounts {
+         if statement.Owner != expectedStatement.Owner || statement.Roster_id != expectedStatement.Roster_id || math.Abs(amount) > 1e-9 {
+            pTest.Errorf("Statement %+v, expected %+v", statement, expectedStatement)
+            break
+         }
+      }
+   }
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func TestLedgerFinalStandings(pTest *testing.T) {
+
+   earlyExit, _ := GetPrize("Early Exit")
+   _, seasonData := newTestSeason(pTest, []ScheduledPrize{{15, earlyExit}})
+   winnersBracket, _ := seasonData.GetBracket(WinnersBracket)
+
+   var ledger Ledger
+   ledger.PostDues(seasonData.mLeagueInfo, 50.0)
+   ledger.PostFinalStandings(seasonData.mLeagueInfo, winnersBracket, []float64{300.0, 150.0, 50.0})
+
+   expectedPayouts := map[string]string{"final-1": "Aces", "final-2": "Dragons", "final-3": "Icemen"}
+   paidIn := 0.0
+
+   for _, entry := range ledger.Entries {
+      if entry.Type == LedgerDues {
+         paidIn += entry.Amount
+      } else if expectedPayouts[entry.Id] != entry.Owner {
+         pTest.Errorf("Payout %s to %s, expected %s", entry.Id, entry.Owner, expectedPayouts[entry.Id])
+      }
+   }
+
+   if len(ledger.Entries) != 13 || paidIn != 500.0 {
+      pTest.Errorf("%d entries with %.2f paid in, expected 13 with 500.00", len(ledger.Entries), paidIn)
+   }
+}
@@ -0,0 +1,285 @@
+package main
+
+import (
+	"math"
+	"slices"
+	"sort"
+)
+
+// Roster slots that do not score, so the solver leaves them out of the lineup.
+var nonStartingSlots = map[string]bool{"BN": true, "IR": true, "TAXI": true}
+
+// The positions each Sleeper flex slot accepts. Any other slot only accepts its own position.
+var flexSlotPositions = map[string][]string{
+   "FLEX": {"RB", "WR", "TE"},
+   "WRRB_FLEX": {"RB", "WR"},
+   "REC_FLEX": {"WR", "TE"},
+   "SUPER_FLEX": {"QB", "RB", "WR", "TE"},
+   "IDP_FLEX": {"DL", "LB", "DB"},
+}
+
+// The order slots are listed in a lineup, matching the order Sleeper shows them in.
+var slotOrder = []string{"QB", "RB", "WR", "TE", "FLEX", "WRRB_FLEX", "REC_FLEX", "SUPER_FLEX", "K", "DEF", "DL", "LB", "DB", "IDP_FLEX"}
+
+// Larger than any achievable lineup score, so an ineligible player is never assigned over an empty slot.
+const ineligibleSlotCost = 1e9
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type LineupSlot struct {
+   Slot string
+   Player_id string
+}
+
+//--------------------------------------------------------------------------------------------------
+// LineupConstraints limits the lineups the solver considers. Excluded players cannot start, and each
+// locked slot keeps its player.
+//--------------------------------------------------------------------------------------------------
+type LineupConstraints struct {
+   ExcludedPlayerIds map[string]bool
+   LockedSlots []LineupSlot
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type OptimalLineup struct {
+   Slots []LineupSlot
+   Points float64
+}
+
+//--------------------------------------------------------------------------------------------------
+// GetOptimalLineup finds the highest scoring lineup the roster could have started by solving the
+// assignment of players to starting slots exactly. A player can fill any slot that accepts one of
+// their fantasy positions. A slot is left empty when nobody eligible is left for it, or when every
+// eligible player scored below zero. Empty slots are omitted from the returned lineup.
+//--------------------------------------------------------------------------------------------------
+func (matchup Matchup) GetOptimalLineup(pPlayers map[string]Player, pRosterPositionCounts map[string]int, pConstraints LineupConstraints) OptimalLineup {
+
+   var lineup OptimalLineup
+   slots := makeStartingSlots(pRosterPositionCounts)
+   lockedPlayerIds := make(map[string]bool)
+
+   for _, lockedSlot := range pConstraints.LockedSlots {
+      if slotIdx := slices.Index(slots, lockedSlot.Slot) ; slotIdx >= 0 {
+         slots = slices.Delete(slots, slotIdx, slotIdx+1)
+         lockedPlayerIds[lockedSlot.Player_id] = true
+
+         lineup.Slots = append(lineup.Slots, lockedSlot)
+         lineup.Points += matchup.Players_points[lockedSlot.Player_id]
+      }
+   }
+
+   var candidates []string
+
+   for _, playerId := range matchup.Players {
+      if !pConstraints.ExcludedPlayerIds[playerId] && !lockedPlayerIds[playerId] {
+         candidates = append(candidates, playerId)
+      }
+   }
+
+   numPlayers := len(candidates)
+
+   // One column per player, followed by one empty slot column per starting slot
+   costs := make([][]float64, len(slots))
+
+   for slotIdx, slot := range slots {
+      costs[slotIdx] = make([]float64, numPlayers + len(slots))
+
+      for playerIdx, playerId := range candidates {
+         if isSlotEligible(slot, pPlayers[playerId]) {
+            costs[slotIdx][playerIdx] = -matchup.Players_points[playerId]
+         } else {
+            costs[slotIdx][playerIdx] = ineligibleSlotCost
+         }
+      }
+   }
+
+   for slotIdx, columnIdx := range solveAssignment(costs) {
+      if columnIdx < numPlayers {
+         playerId := candidates[columnIdx]
+         lineup.Slots = append(lineup.Slots, LineupSlot{slots[slotIdx], playerId})
+         lineup.Points += matchup.Players_points[playerId]
+      }
+   }
+
+   sort.SliceStable(lineup.Slots, func(i, j int) bool {
+      return getSlotOrder(lineup.Slots[i].Slot) < getSlotOrder(lineup.Slots[j].Slot)
+   })
+
+   return lineup
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (lineup OptimalLineup) GetPlayerIds() []string {
+   var playerIds []string
+
+   for _, lineupSlot := range lineup.Slots {
+      playerIds = append(playerIds, lineupSlot.Player_id)
+   }
+
+   return playerIds
+}
+
+//--------------------------------------------------------------------------------------------------
+// GetStartingSlots lists the league's starting slots in the same order as a matchup's Starters.
+//--------------------------------------------------------------------------------------------------
+func (league League) GetStartingSlots() []string {
+   var slots []string
+
+   for _, rosterPosition := range league.Roster_positions {
+      if !nonStartingSlots[rosterPosition] {
+         slots = append(slots, rosterPosition)
+      }
+   }
+
+   return slots
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func makeStartingSlots(pRosterPositionCounts map[string]int) []string {
+   var slots []string
+
+   for slot, count := range pRosterPositionCounts {
+      if !nonStartingSlots[slot] {
+         for idx := 0 ; idx < count ; idx++ {
+            slots = append(slots, slot)
+         }
+      }
+   }
+
+   sort.SliceStable(slots, func(i, j int) bool {
+      lhsOrder, rhsOrder := getSlotOrder(slots[i]), getSlotOrder(slots[j])
+
+      if lhsOrder != rhsOrder {
+         return lhsOrder < rhsOrder
+      }
+
+      return slots[i] < slots[j]
+   })
+
+   return slots
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func getSlotOrder(pSlot string) int {
+   if order := slices.Index(slotOrder, pSlot) ; order >= 0 {
+      return order
+   }
+
+   return len(slotOrder)
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func isSlotEligible(pSlot string, pPlayer Player) bool {
+
+   slotPositions, isFlexSlot := flexSlotPositions[pSlot]
+
+   if !isFlexSlot {
+      slotPositions = []string{pSlot}
+   }
+
+   for _, position := range pPlayer.GetFantasyPositions() {
+      if slices.Contains(slotPositions, position) {
+         return true
+      }
+   }
+
+   return false
+}
+
+//--------------------------------------------------------------------------------------------------
+// solveAssignment assigns each row a distinct column so that the total cost is minimal, using the
+// Hungarian algorithm. Every row must have at least as many columns as there are rows. The returned
+// slice holds the column assigned to each row.
+//--------------------------------------------------------------------------------------------------
+func solveAssignment(pCosts [][]float64) []int {
+
+   numRows := len(pCosts)
+
+   if numRows == 0 {
+      return nil
+   }
+
+   numColumns := len(pCosts[0])
+
+   // Potentials and matches are 1-indexed, with row and column 0 used as sentinels
+   rowPotentials := make([]float64, numRows+1)
+   columnPotentials := make([]float64, numColumns+1)
+   columnRows := make([]int, numColumns+1)
+   previousColumns := make([]int, numColumns+1)
+
+   for row := 1 ; row <= numRows ; row++ {
+
+      columnRows[0] = row
+      column := 0
+      minSlack := make([]float64, numColumns+1)
+      visited := make([]bool, numColumns+1)
+
+      for idx := range minSlack {
+         minSlack[idx] = math.Inf(1)
+      }
+
+      for columnRows[column] != 0 {
+
+         visited[column] = true
+         curRow := columnRows[column]
+         delta := math.Inf(1)
+         nextColumn := 0
+
+         for curColumn := 1 ; curColumn <= numColumns ; curColumn++ {
+            if visited[curColumn] {
+               continue
+            }
+
+            slack := pCosts[curRow-1][curColumn-1] - rowPotentials[curRow] - columnPotentials[curColumn]
+
+            if slack < minSlack[curColumn] {
+               minSlack[curColumn] = slack
+               previousColumns[curColumn] = column
+            }
+
+            if minSlack[curColumn] < delta {
+               delta = minSlack[curColumn]
+               nextColumn = curColumn
+            }
+         }
+
+         for curColumn := 0 ; curColumn <= numColumns ; curColumn++ {
+            if visited[curColumn] {
+               rowPotentials[columnRows[curColumn]] += delta
+               columnPotentials[curColumn] -= delta
+            } else {
+               minSlack[curColumn] -= delta
+            }
+         }
+
+         column = nextColumn
+      }
+
+      for column != 0 {
+         previousColumn := previousColumns[column]
+         columnRows[column] = columnRows[previousColumn]
+         column = previousColumn
+      }
+   }
+
+   assignment := make([]int, numRows)
+
+   for column := 1 ; column <= numColumns ; column++ {
+      if columnRows[column] != 0 {
+         assignment[columnRows[column]-1] = column-1
+      }
+   }
+
+   return assignment
+}
@@ -0,0 +1,289 @@
+package main
+
+import (
+	"cmp"
+	"math"
+	"math/rand"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+)
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type testLineupPlayer struct {
+   id string
+   positions []string
+   points float64
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func makeTestLineupMatchup(pTestPlayers []testLineupPlayer) (Matchup, map[string]Player) {
+   var matchup Matchup
+   matchup.Players_points = make(map[string]float64)
+   players := make(map[string]Player)
+
+   for _, testPlayer := range pTestPlayers {
+      matchup.Players = append(matchup.Players, testPlayer.id)
+      matchup.Players_points[testPlayer.id] = testPlayer.points
+      players[testPlayer.id] = Player{Position: testPlayer.positions[0], Fantasy_positions: testPlayer.positions}
+   }
+
+   return matchup, players
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func TestOptimalLineupSlotTypes(pTest *testing.T) {
+
+   testCases := []struct {
+      name string
+      slots []string
+      players []testLineupPlayer
+      expectedPoints float64
+      expectedPlayerIds []string
+   }{
+      {
+         "Multiple Fantasy Positions",
+         []string{"RB", "WR", "BN"},
+         []testLineupPlayer{{"a", []string{"RB", "WR"}, 20.0}, {"b", []string{"RB"}, 15.0}},
+         35.0,
+         []string{"b", "a"},
+      },
+      {
+         "Super Flex",
+         []string{"QB", "RB", "SUPER_FLEX"},
+         []testLineupPlayer{{"a", []string{"QB"}, 25.0}, {"b", []string{"QB"}, 30.0}, {"c", []string{"RB"}, 10.0}, {"d", []string{"RB"}, 8.0}},
+         65.0,
+         []string{"b", "c", "a"},
+      },
+      {
+         "Receiver Flexes",
+         []string{"WR", "TE", "REC_FLEX", "WRRB_FLEX"},
+         []testLineupPlayer{{"a", []string{"WR"}, 12.0}, {"b", []string{"TE"}, 9.0}, {"c", []string{"TE"}, 14.0}, {"d", []string{"RB"}, 11.0}, {"e", []string{"WR"}, 3.0}},
+         46.0,
+         []string{"a", "c", "d", "b"},
+      },
+      {
+         "Individual Defensive Players",
+         []string{"DL", "LB", "DB", "IDP_FLEX", "BN"},
+         []testLineupPlayer{{"a", []string{"DL"}, 6.0}, {"b", []string{"LB"}, 9.0}, {"c", []string{"LB"}, 7.0}, {"d", []string{"DB"}, 4.0}, {"e", []string{"DL", "LB"}, 8.0}},
+         28.0,
+         []string{"e", "b", "d", "c"},
+      },
+      {
+         "Unfilled And Negative Slots",
+         []string{"QB", "K", "DEF"},
+         []testLineupPlayer{{"a", []string{"QB"}, 18.0}, {"b", []string{"DEF"}, -3.0}},
+         18.0,
+         []string{"a"},
+      },
+   }
+
+   for _, testCase := range testCases {
+
+      league := League{Roster_positions: testCase.slots}
+      league.populateRosterPositionCounts()
+
+      matchup, players := makeTestLineupMatchup(testCase.players)
+      lineup := matchup.GetOptimalLineup(players, league.mRosterPositionCounts, LineupConstraints{})
+
+      if math.Abs(lineup.Points - testCase.expectedPoints) > 1e-9 {
+         pTest.Errorf("%s: Points %f, expected %f", testCase.name, lineup.Points, testCase.expectedPoints)
+      }
+
+      if playerIds := lineup.GetPlayerIds() ; !slices.Equal(playerIds, testCase.expectedPlayerIds) {
+         pTest.Errorf("%s: Lineup %v, expected %v", testCase.name, playerIds, testCase.expectedPlayerIds)
+      }
+   }
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func TestOptimalLineupConstraints(pTest *testing.T) {
+
+   league := League{Roster_positions: []string{"QB", "RB", "FLEX", "BN", "BN", "IR"}}
+   league.populateRosterPositionCounts()
+
+   matchup, players := makeTestLineupMatchup([]testLineupPlayer{
+      {"a", []string{"QB"}, 20.0},
+      {"b", []string{"RB"}, 4.0},
+      {"c", []string{"RB"}, 12.0},
+      {"d", []string{"WR"}, 18.0},
+      {"e", []string{"RB"}, 30.0},
+   })
+
+   var constraints LineupConstraints
+   constraints.ExcludedPlayerIds = map[string]bool{"e": true}
+   constraints.LockedSlots = []LineupSlot{{"RB", "b"}}
+
+   lineup := matchup.GetOptimalLineup(players, league.mRosterPositionCounts, constraints)
+
+   if lineup.Points != 42.0 {
+      pTest.Errorf("Points %f, expected 42", lineup.Points)
+   }
+
+   if playerIds := lineup.GetPlayerIds() ; !slices.Equal(playerIds, []string{"a", "b", "d"}) {
+      pTest.Errorf("Lineup %v, expected [a b d]", playerIds)
+   }
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func TestOptimalLineupMatchesBruteForce(pTest *testing.T) {
+
+   random := rand.New(rand.NewSource(1))
+   positions := [][]string{{"QB"}, {"RB"}, {"WR"}, {"TE"}, {"RB", "WR"}, {"WR", "TE"}}
+   slots := []string{"QB", "RB", "WR", "TE", "FLEX", "SUPER_FLEX", "REC_FLEX"}
+
+   for iteration := 0 ; iteration < 200 ; iteration++ {
+
+      var testPlayers []testLineupPlayer
+
+      for idx := 0 ; idx < 3 + random.Intn(6) ; idx++ {
+         testPlayers = append(testPlayers, testLineupPlayer{strconv.Itoa(idx), positions[random.Intn(len(positions))], float64(random.Intn(40) - 5)})
+      }
+
+      league := League{Roster_positions: slots[:1 + random.Intn(len(slots))]}
+      league.populateRosterPositionCounts()
+
+      matchup, players := makeTestLineupMatchup(testPlayers)
+      lineup := matchup.GetOptimalLineup(players, league.mRosterPositionCounts, LineupConstraints{})
+      expectedPoints := getBruteForceLineupPoints(makeStartingSlots(league.mRosterPositionCounts), matchup, players, make(map[string]bool))
+
+      if math.Abs(lineup.Points - expectedPoints) > 1e-9 {
+         pTest.Fatalf("Iteration %d: Points %f, expected %f", iteration, lineup.Points, expectedPoints)
+      }
+   }
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func getBruteForceLineupPoints(pSlots []string, pMatchup Matchup, pPlayers map[string]Player, pUsed map[string]bool) float64 {
+
+   if len(pSlots) == 0 {
+      return 0.0
+   }
+
+   // Leaving the slot empty is always an option
+   maxPoints := getBruteForceLineupPoints(pSlots[1:], pMatchup, pPlayers, pUsed)
+
+   for _, playerId := range pMatchup.Players {
+      if !pUsed[playerId] && isSlotEligible(pSlots[0], pPlayers[playerId]) {
+         pUsed[playerId] = true
+         maxPoints = math.Max(maxPoints, pMatchup.Players_points[playerId] + getBruteForceLineupPoints(pSlots[1:], pMatchup, pPlayers, pUsed))
+         pUsed[playerId] = false
+      }
+   }
+
+   return maxPoints
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func TestBestManagerLineupConstraints(pTest *testing.T) {
+
+   bestManager, _ := GetPrize("Best Manager")
+   _, seasonData := newTestSeason(pTest, []ScheduledPrize{{8, bestManager}})
+
+   // Find the Aces' best bench player in week 8
+   acesMatchup, _ := GetMatchupRoster(seasonData.mMatchups[8], 1)
+   benchPlayers := acesMatchup.GetBenchPlayers()
+
+   slices.SortStableFunc(benchPlayers, func(pLhs string, pRhs string) int {
+      return cmp.Compare(acesMatchup.Players_points[pRhs], acesMatchup.Players_points[pLhs])
+   })
+
+   baseline := findPrizeEntry(GetWeekSummary(bestManager, seasonData, 8).PrizeEntries, "Aces")
+
+   if baseline == nil || !slices.Contains(getEvidencePlayerIds(baseline.Evidence.OptimalLineup), benchPlayers[0]) {
+      pTest.Fatalf("Expected the optimal lineup to start bench player %s", benchPlayers[0])
+   }
+
+   // Sleeper only reports the current reserve squad, so it leaves earlier weeks alone
+   seasonData.mLeagueInfo.mRosters[0].Reserve = []string{benchPlayers[0]}
+   earlierWeek := findPrizeEntry(GetWeekSummary(bestManager, seasonData, 8).PrizeEntries, "Aces")
+
+   if math.Abs(earlierWeek.Score - baseline.Score) > 1e-9 {
+      pTest.Errorf("Reserve player %s changed an earlier week's score from %f to %f", benchPlayers[0], baseline.Score, earlierWeek.Score)
+   }
+
+   seasonData.mRosterWeek = 8
+   reserve := findPrizeEntry(GetWeekSummary(bestManager, seasonData, 8).PrizeEntries, "Aces")
+
+   if slices.Contains(getEvidencePlayerIds(reserve.Evidence.OptimalLineup), benchPlayers[0]) || reserve.Score <= baseline.Score {
+      pTest.Errorf("Reserve player %s was not excluded: Score %f, baseline %f", benchPlayers[0], reserve.Score, baseline.Score)
+   }
+
+   // Starters that have since moved to the reserve or taxi squad still count towards the optimal lineup
+   starters := slices.DeleteFunc(slices.Clone(acesMatchup.Starters), func(pStarter string) bool { return pStarter == EmptyPlayerId })
+   seasonData.mLeagueInfo.mRosters[0].Reserve = starters[:len(starters)/2]
+   seasonData.mLeagueInfo.mRosters[0].Taxi = starters[len(starters)/2:]
+
+   for _, rosterWeek := range []int{8, 15} {
+      seasonData.mRosterWeek = rosterWeek
+      startersMoved := findPrizeEntry(GetWeekSummary(bestManager, seasonData, 8).PrizeEntries, "Aces")
+
+      if startersMoved.Score > 100.0 || math.Abs(startersMoved.Score - baseline.Score) > 1e-9 {
+         pTest.Errorf("Roster week %d: Score %f with the starters on reserve and taxi, expected %f", rosterWeek, startersMoved.Score, baseline.Score)
+      }
+   }
+
+   // Lock every player on the Aces by giving them a team whose game kicked off before the lineup lock
+   seasonData.mLeagueInfo.mRosters[0].Reserve = nil
+   seasonData.mLeagueInfo.mRosters[0].Taxi = nil
+   lineupLock := time.Date(2023, time.October, 29, 13, 0, 0, 0, time.UTC)
+   seasonData.mGameTimes = map[int]WeekGameTimes{8: {lineupLock, map[string]time.Time{"TST": lineupLock.Add(-72 * time.Hour)}}}
+
+   for _, playerId := range acesMatchup.Players {
+      player := seasonData.mPlayers[playerId]
+      player.Team = "TST"
+      seasonData.mPlayers[playerId] = player
+   }
+
+   locked := findPrizeEntry(GetWeekSummary(bestManager, seasonData, 8).PrizeEntries, "Aces")
+
+   if math.Abs(locked.Score - 100.0) > 1e-9 {
+      pTest.Errorf("Locked lineup score %f, expected 100", locked.Score)
+   }
+}
+
+//--------------------------------------------------------------------------------------------------
+// A team whose optimal lineup scores nothing has no efficiency, so it cannot win Best Manager.
+//--------------------------------------------------------------------------------------------------
+func TestBestManagerWithoutOptimalLineupPoints(pTest *testing.T) {
+
+   bestManager, _ := GetPrize("Best Manager")
+   _, seasonData := newTestSeason(pTest, []ScheduledPrize{{8, bestManager}})
+
+   aces := &seasonData.mMatchups[8][0]
+
+   for playerId := range aces.Players_points {
+      aces.Players_points[playerId] = 0.0
+   }
+
+   for idx := range aces.Starters_points {
+      aces.Starters_points[idx] = 0.0
+   }
+
+   summary := GetWeekSummary(bestManager, seasonData, 8)
+
+   if acesEntry := findPrizeEntry(summary.IneligibleEntries, "Aces") ; acesEntry == nil || acesEntry.Reason != "No Optimal Lineup Points" {
+      pTest.Errorf("Aces entry %+v, expected them to be ineligible without optimal lineup points", acesEntry)
+   }
+
+   for _, prizeEntry := range summary.PrizeEntries {
+      if math.IsNaN(prizeEntry.Score) || prizeEntry.Owner == "Aces" {
+         pTest.Errorf("Prize entry %s scored %f, expected only eligible teams with a score", prizeEntry.Owner, prizeEntry.Score)
+      }
+   }
+}
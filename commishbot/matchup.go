@@ -1,13 +1,17 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
-	"math"
-	"sort"
+	"fmt"
 	"strconv"
 )
 
+// Sleeper fills starting slots that were left empty with this player Id.
+const EmptyPlayerId = "0"
+
+var ErrNoOpponent = errors.New("no opponent")
+
 //--------------------------------------------------------------------------------------------------
 //
 //--------------------------------------------------------------------------------------------------
@@ -24,22 +28,29 @@ type Matchup struct {
 //--------------------------------------------------------------------------------------------------
 //
 //--------------------------------------------------------------------------------------------------
-func GetMatchupsData(pLeagueId string, pWeek int) string {
-   return GetHttpResponse("https://api.sleeper.app/v1/league/" + pLeagueId + "/matchups/" + strconv.Itoa(pWeek))
+func (client *SleeperClient) GetMatchupsData(pContext context.Context, pLeagueId string, pWeek int) (string, error) {
+   return client.getHttpResponse(pContext, client.mBaseUrl + "/league/" + pLeagueId + "/matchups/" + strconv.Itoa(pWeek), client.getMatchupsCacheTtl(pContext, pWeek))
 }
 
 //--------------------------------------------------------------------------------------------------
 //
 //--------------------------------------------------------------------------------------------------
-func GetMatchups(pLeagueId string, pWeek int) []Matchup {
+func (client *SleeperClient) GetMatchups(pContext context.Context, pLeagueId string, pWeek int) ([]Matchup, error) {
 
-   matchupsData := GetMatchupsData(pLeagueId, pWeek)
+   matchupsData, err := client.GetMatchupsData(pContext, pLeagueId, pWeek)
+
+   if err != nil {
+      return nil, err
+   }
 
    var matchups []Matchup
-   err := json.Unmarshal([]byte(matchupsData), &matchups)
-   check(err)
+   err = unmarshalSleeperData(matchupsData, "league " + pLeagueId + " week " + strconv.Itoa(pWeek) + " matchups", &matchups)
+
+   if err != nil {
+      return nil, err
+   }
 
-   return matchups
+   return matchups, nil
 }
 
 //--------------------------------------------------------------------------------------------------
@@ -53,7 +64,7 @@ func GetMatchupRoster(pMatchups []Matchup, pRosterId int) (Matchup, error) {
       }
    }
 
-   return Matchup{}, errors.New("GetMatchupRoster: Failed to find roster (Id: " + strconv.Itoa(pRosterId) + ")")
+   return Matchup{}, fmt.Errorf("GetMatchupRoster: Failed to find roster (Id: %d): %w", pRosterId, ErrNotFound)
 }
 
 //--------------------------------------------------------------------------------------------------
@@ -67,13 +78,18 @@ func GetMatchupOpponentRoster(pMatchups []Matchup, pRosterId int) (Matchup, erro
       return Matchup{}, err
    }
 
+   // Teams on a bye have a null matchup id, which must not pair them with each other
+   if matchupRoster.Matchup_id == 0 {
+      return Matchup{}, fmt.Errorf("GetMatchupOpponentRoster: Roster (Id: %d) has no matchup: %w", pRosterId, ErrNoOpponent)
+   }
+
    for _, matchup := range pMatchups {
       if matchup.Matchup_id == matchupRoster.Matchup_id && matchup.Roster_id != matchupRoster.Roster_id {
          return matchup, nil
       }
    }
 
-   return Matchup{}, errors.New("GetMatchupOpponentRoster: Failed to find opponent roster (Id: " + strconv.Itoa(pRosterId) + ")")
+   return Matchup{}, fmt.Errorf("GetMatchupOpponentRoster: Failed to find opponent roster (Id: %d): %w", pRosterId, ErrNoOpponent)
 }
 
 //--------------------------------------------------------------------------------------------------
@@ -136,108 +152,3 @@ func (matchup Matchup) GetBenchPlayerPoints() []float64 {
 
    return benchPlayerPoints
 }
-
-//--------------------------------------------------------------------------------------------------
-//
-//--------------------------------------------------------------------------------------------------
-func (matchup Matchup) GetMaxRosterPoints(pPlayers map[string]Player, pStarterPositionCounts map[string]int) float64 {
-
-   var maxStarterPoints []float64
-
-   maxQbPoints, _ := matchup.getMaxPlayerPoints("QB", pPlayers, pStarterPositionCounts)
-   maxStarterPoints = append(maxStarterPoints, maxQbPoints...)
-
-   maxRbPoints, remainingRbPoints := matchup.getMaxPlayerPoints("RB", pPlayers, pStarterPositionCounts)
-   maxStarterPoints = append(maxStarterPoints, maxRbPoints...)
-
-   maxWrPoints, remainingWrPoints := matchup.getMaxPlayerPoints("WR", pPlayers, pStarterPositionCounts)
-   maxStarterPoints = append(maxStarterPoints, maxWrPoints...)
-
-   maxTePoints, remainingTePoints := matchup.getMaxPlayerPoints("TE", pPlayers, pStarterPositionCounts)
-   maxStarterPoints = append(maxStarterPoints, maxTePoints...)
-
-   maxKPoints, _ := matchup.getMaxPlayerPoints("K", pPlayers, pStarterPositionCounts)
-   maxStarterPoints = append(maxStarterPoints, maxKPoints...)
-
-   maxDefPoints, _ := matchup.getMaxPlayerPoints("DEF", pPlayers, pStarterPositionCounts)
-   maxStarterPoints = append(maxStarterPoints, maxDefPoints...)
-
-   var flexPoints []float64
-   numFlexPositions := pStarterPositionCounts["FLEX"]
-
-   flexRbPoints, _ := removeElements(remainingRbPoints, numFlexPositions)
-   flexPoints = append(flexPoints, flexRbPoints...)
-
-   flexWrPoints, _ := removeElements(remainingWrPoints, numFlexPositions)
-   flexPoints = append(flexPoints, flexWrPoints...)
-
-   flexTePoints, _ := removeElements(remainingTePoints, numFlexPositions)
-   flexPoints = append(flexPoints, flexTePoints...)
-
-   sort.Sort(sort.Reverse(sort.Float64Slice(flexPoints)))
-   maxFlexPoints, _ := removeElements(flexPoints, numFlexPositions)
-   maxStarterPoints = append(maxStarterPoints, maxFlexPoints...)
-
-   maxPoints := 0.0
-
-   for _, curMaxStarterPoints := range maxStarterPoints {
-      maxPoints += curMaxStarterPoints
-   }
-
-   return maxPoints
-}
-
-//--------------------------------------------------------------------------------------------------
-//
-//--------------------------------------------------------------------------------------------------
-func (matchup Matchup) getMaxPlayerPoints(pPosition string, pPlayers map[string]Player, pPositionCounts map[string]int) ([]float64, []float64) {
-
-   playerPoints := matchup.getPlayerPoints(pPosition, pPlayers)
-   sort.Sort(sort.Reverse(sort.Float64Slice(playerPoints)))
-
-   maxPlayerPoints, remainingPlayerPoints := removeElements(playerPoints, pPositionCounts[pPosition])
-
-   return maxPlayerPoints, remainingPlayerPoints
-}
-
-//--------------------------------------------------------------------------------------------------
-//
-//--------------------------------------------------------------------------------------------------
-func (matchup Matchup) getPlayerPoints(pPosition string, pPlayers map[string]Player) []float64 {
-   var playerPoints []float64
-   players := matchup.getPlayers(pPosition, pPlayers)
-
-   for _, player := range players {
-      playerPoints = append(playerPoints, matchup.Players_points[player])
-   }
-
-   return playerPoints
-}
-
-//--------------------------------------------------------------------------------------------------
-//
-//--------------------------------------------------------------------------------------------------
-func (matchup Matchup) getPlayers(pPosition string, pPlayers map[string]Player) []string {
-   var playerIds []string
-
-   for _, playerId := range matchup.Players {
-      player := pPlayers[playerId]
-
-      if player.Position == pPosition {
-         playerIds = append(playerIds, playerId)
-      }
-   }
-
-   return playerIds
-}
-
-//--------------------------------------------------------------------------------------------------
-//
-//--------------------------------------------------------------------------------------------------
-func removeElements(pSlice []float64, pNumElements int) ([]float64, []float64) {
-
-   clampedNumElements := int(math.Min(float64(len(pSlice)), float64(pNumElements)))
-   removedElements, remainingElements := pSlice[0:clampedNumElements], pSlice[clampedNumElements:]
-
-   return removedElements, remainingElements
-}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"strconv"
+	"time"
+)
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type NflState struct {
+   Week int
+   Season string
+   Season_type string
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (client *SleeperClient) GetNflStateData(pContext context.Context) (string, error) {
+   return client.getHttpResponse(pContext, client.mBaseUrl + "/state/nfl", stateCacheTtl)
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (client *SleeperClient) GetNflState(pContext context.Context) (NflState, error) {
+
+   nflStateData, err := client.GetNflStateData(pContext)
+
+   if err != nil {
+      return NflState{}, err
+   }
+
+   var nflState NflState
+   err = unmarshalSleeperData(nflStateData, "nfl state", &nflState)
+
+   if err != nil {
+      return NflState{}, err
+   }
+
+   return nflState, nil
+}
+
+//--------------------------------------------------------------------------------------------------
+// GetCompletedWeeks returns how many of the season's regular season weeks have been played.
+//--------------------------------------------------------------------------------------------------
+func (nflState NflState) GetCompletedWeeks(pYear int, pRegularSeasonWeeks int) int {
+
+   season, err := strconv.Atoi(nflState.Season)
+
+   if err != nil || season > pYear {
+      return pRegularSeasonWeeks
+   }
+
+   if season < pYear {
+      return 0
+   }
+
+   switch nflState.Season_type {
+   case string(SeasonTypePre):
+      return 0
+
+   case string(SeasonTypeRegular):
+      return max(0, min(nflState.Week-1, pRegularSeasonWeeks))
+   }
+
+   // The postseason and the offseason that follows it
+   return pRegularSeasonWeeks
+}
+
+//--------------------------------------------------------------------------------------------------
+// GetCurrentWeek returns the fantasy week of pYear being played, or 0 outside the regular season.
+//--------------------------------------------------------------------------------------------------
+func (nflState NflState) GetCurrentWeek(pYear int) int {
+
+   if season, err := strconv.Atoi(nflState.Season) ; err != nil || season != pYear || nflState.Season_type != string(SeasonTypeRegular) {
+      return 0
+   }
+
+   return nflState.Week
+}
+
+//--------------------------------------------------------------------------------------------------
+// IsWeekComplete reports whether every game of the week has been played.
+//--------------------------------------------------------------------------------------------------
+func (nflState NflState) IsWeekComplete(pSeasonType SeasonType, pYear int, pWeek int) bool {
+
+   season, err := strconv.Atoi(nflState.Season)
+
+   if err != nil || season != pYear {
+      return err == nil && season > pYear
+   }
+
+   switch pSeasonType {
+   case SeasonTypePre:
+      return nflState.Season_type != string(SeasonTypePre) || pWeek < nflState.Week
+
+   case SeasonTypeRegular:
+      return nflState.GetCompletedWeeks(pYear, pWeek) >= pWeek
+
+   case SeasonTypePost:
+      return nflState.Season_type == "off" || (nflState.Season_type == string(SeasonTypePost) && pWeek < nflState.Week)
+   }
+
+   return false
+}
+
+//--------------------------------------------------------------------------------------------------
+// Matchups for weeks before the current NFL week can no longer change and are cached forever, although
+// a response cached while the week was still being played is revalidated first. The matchups endpoint
+// does not carry the season, so a past season's week that is numbered at or after the current week is
+// only cached for the default ttl, which errs on the side of refetching.
+//--------------------------------------------------------------------------------------------------
+func (client *SleeperClient) getMatchupsCacheTtl(pContext context.Context, pWeek int) time.Duration {
+
+   if client.mCache == nil {
+      return defaultCacheTtl
+   }
+
+   nflState, err := client.GetNflState(pContext)
+
+   if err != nil {
+      return defaultCacheTtl
+   }
+
+   switch nflState.Season_type {
+   case string(SeasonTypeRegular):
+      if pWeek < nflState.Week {
+         return CacheForever
+      }
+
+   case string(SeasonTypePost):
+      return CacheForever
+   }
+
+   return defaultCacheTtl
+}
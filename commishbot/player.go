@@ -1,8 +1,9 @@
 package main
 
 import (
-	"encoding/json"
-	"os"
+	"context"
+	"sort"
+	"strings"
 )
 
 //--------------------------------------------------------------------------------------------------
@@ -13,32 +14,46 @@ type Player struct {
    Last_name string
    Full_name string
    Position string
+   Fantasy_positions []string
+   Team string
 }
 
 //--------------------------------------------------------------------------------------------------
 //
 //--------------------------------------------------------------------------------------------------
-func GetPlayerData() string {
-   return GetHttpResponse("https://api.sleeper.app/v1/players/nfl")
+type PlayerSearchResult struct {
+   Player_id string
+   Name string
+   Position string
+   Team string
 }
 
 //--------------------------------------------------------------------------------------------------
 //
 //--------------------------------------------------------------------------------------------------
-func GetPlayers() map[string]Player {
+func (client *SleeperClient) GetPlayerData(pContext context.Context) (string, error) {
+   return client.getHttpResponse(pContext, client.mBaseUrl + "/players/nfl", playersCacheTtl)
+}
 
-   // TODO (tknack): Temporarily disable player data retrieval to avoid stressing the server
-   // playerData := GetPlayerData()
-   playerDataBytes, err := os.ReadFile("./Nfl.2023.Players.json")
-   check(err)
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (client *SleeperClient) GetPlayers(pContext context.Context) (map[string]Player, error) {
 
-   playerData := string(playerDataBytes)
+   playerData, err := client.GetPlayerData(pContext)
+
+   if err != nil {
+      return nil, err
+   }
 
    playerMap := make(map[string]Player)
-   err = json.Unmarshal([]byte(playerData), &playerMap)
-   check(err)
+   err = unmarshalSleeperData(playerData, "players", &playerMap)
+
+   if err != nil {
+      return nil, err
+   }
 
-   return playerMap
+   return playerMap, nil
 }
 
 //--------------------------------------------------------------------------------------------------
@@ -56,3 +71,47 @@ func GetPlayerName(pPlayers map[string]Player, pPlayerId string) string {
 
    return pPlayerId
 }
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (player Player) GetFantasyPositions() []string {
+
+   if len(player.Fantasy_positions) > 0 {
+      return player.Fantasy_positions
+   }
+
+   if player.Position != "" {
+      return []string{player.Position}
+   }
+
+   return nil
+}
+
+//--------------------------------------------------------------------------------------------------
+// SearchPlayers returns the players whose name contains pQuery, ignoring case, sorted by name.
+//--------------------------------------------------------------------------------------------------
+func SearchPlayers(pPlayers map[string]Player, pQuery string) []PlayerSearchResult {
+
+   query := strings.ToLower(strings.TrimSpace(pQuery))
+   var searchResults []PlayerSearchResult
+
+   for playerId, player := range pPlayers {
+
+      name := GetPlayerName(pPlayers, playerId)
+
+      if strings.Contains(strings.ToLower(name), query) {
+         searchResults = append(searchResults, PlayerSearchResult{playerId, name, player.Position, player.Team})
+      }
+   }
+
+   sort.Slice(searchResults, func(i, j int) bool {
+      if searchResults[i].Name != searchResults[j].Name {
+         return searchResults[i].Name < searchResults[j].Name
+      }
+
+      return searchResults[i].Player_id < searchResults[j].Player_id
+   })
+
+   return searchResults
+}
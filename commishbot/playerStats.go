@@ -1,9 +1,11 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
+	"io/fs"
 	"os"
+	"path/filepath"
 	"strconv"
 )
 
@@ -22,34 +24,130 @@ type PlayerStats struct {
 //--------------------------------------------------------------------------------------------------
 //
 //--------------------------------------------------------------------------------------------------
-func GetPlayerStatsData(pYear int, pWeek int) string {
-   return GetHttpResponse("https://api.sleeper.app/v1/stats/nfl/regular/" + strconv.Itoa(pYear) + "/" + strconv.Itoa(pWeek))
+func (client *SleeperClient) GetPlayerStatsData(pContext context.Context, pSeasonType SeasonType, pYear int, pWeek int) (string, error) {
+   return client.getHttpResponse(pContext, client.mBaseUrl + "/stats/nfl/" + string(pSeasonType) + "/" + strconv.Itoa(pYear) + "/" + strconv.Itoa(pWeek), statsCacheTtl)
 }
 
 //--------------------------------------------------------------------------------------------------
-//
+// When a snapshot directory is configured, a week's stats are read from its snapshot file if one
+// exists and are otherwise fetched from Sleeper and written to it once the week is over, so each
+// completed week is only downloaded once. CacheModeRefresh skips the snapshot and replaces it.
 //--------------------------------------------------------------------------------------------------
-func GetPlayerStats(pYear int, pWeek int) map[string]PlayerStats {
+func (client *SleeperClient) GetPlayerStats(pContext context.Context, pSeasonType SeasonType, pYear int, pWeek int) (map[string]PlayerStats, error) {
+
+   if !pSeasonType.IsValid() {
+      return nil, errors.New("GetPlayerStats: Unknown season type " + string(pSeasonType))
+   }
 
-   // TODO (tknack): Temporarily disable player stats retrieval to avoid stressing the server
-   // playerStatsData := GetPlayerStatsData(pYear, pWeek)
+   description := strconv.Itoa(pYear) + " " + string(pSeasonType) + " week " + strconv.Itoa(pWeek) + " stats"
 
-   targetWeek := 12
+   playerStatsData, err := client.readPlayerStatsSnapshot(pSeasonType, pYear, pWeek)
 
-   if pWeek != targetWeek {
-      err := errors.New("Cannot get player stats for week " + strconv.Itoa(pWeek) + " since player stats are locked to week " + strconv.Itoa(targetWeek))
-      panic(err)
+   if err != nil {
+      return nil, err
    }
 
-   playerStatsDataBytes, err := os.ReadFile("./Nfl.2023.Stats.Week" + strconv.Itoa(targetWeek) + ".json")
-   check(err)
-   playerStatsData := string(playerStatsDataBytes)
+   isFetched := playerStatsData == ""
+
+   if isFetched {
+      playerStatsData, err = client.GetPlayerStatsData(pContext, pSeasonType, pYear, pWeek)
+
+      if err != nil {
+         return nil, err
+      }
+   }
 
    playerStatsMap := make(map[string]PlayerStats)
-   err = json.Unmarshal([]byte(playerStatsData), &playerStatsMap)
-   check(err)
+   err = unmarshalSleeperData(playerStatsData, description, &playerStatsMap)
+
+   if err != nil {
+      return nil, err
+   }
+
+   // Only freshly fetched stats are snapshotted, which spares a snapshot hit the NFL state request
+   if isFetched && client.mSnapshotDir != "" && client.isStatsWeekComplete(pContext, pSeasonType, pYear, pWeek) {
+      err = client.writePlayerStatsSnapshot(pSeasonType, pYear, pWeek, playerStatsData)
+
+      if err != nil {
+         return nil, err
+      }
+   }
+
+   return playerStatsMap, nil
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (client *SleeperClient) getPlayerStatsSnapshotPath(pSeasonType SeasonType, pYear int, pWeek int) string {
+
+   fileName := "Nfl." + strconv.Itoa(pYear) + ".Stats."
+
+   if pSeasonType != SeasonTypeRegular {
+      fileName += string(pSeasonType) + "."
+   }
+
+   fileName += "Week" + strconv.Itoa(pWeek) + ".json"
+
+   return filepath.Join(client.mSnapshotDir, fileName)
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (client *SleeperClient) readPlayerStatsSnapshot(pSeasonType SeasonType, pYear int, pWeek int) (string, error) {
+
+   if client.mSnapshotDir == "" || client.mSnapshotMode == CacheModeRefresh {
+      return "", nil
+   }
+
+   playerStatsDataBytes, err := os.ReadFile(client.getPlayerStatsSnapshotPath(pSeasonType, pYear, pWeek))
+
+   if errors.Is(err, fs.ErrNotExist) {
+      return "", nil
+   }
+
+   if err != nil {
+      return "", err
+   }
+
+   return string(playerStatsDataBytes), nil
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (client *SleeperClient) writePlayerStatsSnapshot(pSeasonType SeasonType, pYear int, pWeek int, pPlayerStatsData string) error {
+
+   if client.mSnapshotDir == "" {
+      return nil
+   }
+
+   snapshotPath := client.getPlayerStatsSnapshotPath(pSeasonType, pYear, pWeek)
+
+   if _, err := os.Stat(snapshotPath) ; err == nil && client.mSnapshotMode != CacheModeRefresh {
+      return nil
+   }
+
+   return writeFileAtomically(snapshotPath, []byte(pPlayerStatsData))
+}
+
+//--------------------------------------------------------------------------------------------------
+// A week still being played is not snapshotted, since its stats would be frozen part way through.
+//--------------------------------------------------------------------------------------------------
+func (client *SleeperClient) isStatsWeekComplete(pContext context.Context, pSeasonType SeasonType, pYear int, pWeek int) bool {
+
+   if client.mSnapshotDir == "" {
+      return false
+   }
+
+   nflState, err := client.GetNflState(pContext)
+
+   if err != nil {
+      return false
+   }
 
-   return playerStatsMap
+   return nflState.IsWeekComplete(pSeasonType, pYear, pWeek)
 }
 
 //--------------------------------------------------------------------------------------------------
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+//--------------------------------------------------------------------------------------------------
+// A completed week's stats are snapshotted and read back, and CacheModeRefresh refetches them and
+// replaces the snapshot.
+//--------------------------------------------------------------------------------------------------
+func TestPlayerStatsSnapshot(pTest *testing.T) {
+
+   server, client := newTestClient(pTest)
+   snapshotDir := pTest.TempDir()
+   client.SetSnapshotDir(snapshotDir, CacheModeNormal)
+
+   statsPath := "/v1/stats/nfl/regular/2023/12"
+   snapshotPath := filepath.Join(snapshotDir, "Nfl.2023.Stats.Week12.json")
+
+   getFumbles := func() float64 {
+      playerStats, err := client.GetPlayerStats(context.Background(), SeasonTypeRegular, testYear, 12)
+
+      if err != nil {
+         pTest.Fatalf("GetPlayerStats: %s", err.Error())
+      }
+
+      return GetNumFumbles(playerStats, "1100")
+   }
+
+   if fumbles := getFumbles() ; fumbles != 1.0 {
+      pTest.Errorf("%.0f fumbles, expected 1", fumbles)
+   }
+
+   if _, err := os.Stat(snapshotPath) ; err != nil {
+      pTest.Fatalf("Completed week 12 was not snapshotted: %s", err.Error())
+   }
+
+   // A snapshot taken part way through the week
+   err := os.WriteFile(snapshotPath, []byte(`{"1100": {"fum_lost": 5}}`), 0644)
+
+   if err != nil {
+      pTest.Fatal(err)
+   }
+
+   stateRequestCount := server.RequestCount("/v1/state/nfl")
+
+   if fumbles := getFumbles() ; fumbles != 5.0 || server.RequestCount(statsPath) != 1 {
+      pTest.Errorf("%.0f fumbles after %d requests, expected the snapshot's 5 without a request", fumbles, server.RequestCount(statsPath))
+   }
+
+   if requestCount := server.RequestCount("/v1/state/nfl") ; requestCount != stateRequestCount {
+      pTest.Errorf("Reading the snapshot requested the NFL state %d times, expected none", requestCount - stateRequestCount)
+   }
+
+   client.SetSnapshotDir(snapshotDir, CacheModeRefresh)
+
+   if fumbles := getFumbles() ; fumbles != 1.0 || server.RequestCount(statsPath) != 2 {
+      pTest.Errorf("%.0f fumbles after %d requests, expected the refetched 1", fumbles, server.RequestCount(statsPath))
+   }
+
+   client.SetSnapshotDir(snapshotDir, CacheModeNormal)
+
+   if fumbles := getFumbles() ; fumbles != 1.0 {
+      pTest.Errorf("%.0f fumbles, expected the replaced snapshot's 1", fumbles)
+   }
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func TestIsWeekComplete(pTest *testing.T) {
+
+   testCases := []struct {
+      nflState NflState
+      seasonType SeasonType
+      year int
+      week int
+      expected bool
+   }{
+      {NflState{15, "2023", "regular"}, SeasonTypeRegular, 2023, 14, true},
+      {NflState{15, "2023", "regular"}, SeasonTypeRegular, 2023, 15, false},
+      {NflState{15, "2023", "regular"}, SeasonTypePost, 2023, 1, false},
+      {NflState{2, "2023", "post"}, SeasonTypePost, 2023, 1, true},
+      {NflState{2, "2023", "post"}, SeasonTypeRegular, 2023, 18, true},
+      {NflState{1, "2023", "pre"}, SeasonTypeRegular, 2023, 1, false},
+      {NflState{1, "2024", "pre"}, SeasonTypeRegular, 2023, 18, true},
+      {NflState{15, "2023", "regular"}, SeasonTypeRegular, 2024, 1, false},
+   }
+
+   for _, testCase := range testCases {
+      if isWeekComplete := testCase.nflState.IsWeekComplete(testCase.seasonType, testCase.year, testCase.week) ; isWeekComplete != testCase.expected {
+         pTest.Errorf("%+v: %d %s week %d complete %t, expected %t", testCase.nflState, testCase.year, testCase.seasonType, testCase.week, isWeekComplete, testCase.expected)
+      }
+   }
+}
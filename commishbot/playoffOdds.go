@@ -0,0 +1,510 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+const defaultPlayoffSimulations = 10000
+
+// Simulations are split into chunks with their own seeds, so the results for a seed do not depend on
+// how many workers run the chunks.
+const simulationChunkSize = 500
+
+//--------------------------------------------------------------------------------------------------
+// ProjectionBlend weights each remaining week's projected team score against the team's average so
+// far, from 0 (average only) to 1 (projection only). Parallelism defaults to the number of CPUs.
+//--------------------------------------------------------------------------------------------------
+type PlayoffOddsOptions struct {
+   Simulations int
+   Seed int64
+   ProjectionBlend float64
+   Parallelism int
+}
+
+//--------------------------------------------------------------------------------------------------
+// MagicNumber is the number of this team's wins or its rivals' losses that clinch a playoff spot,
+// and is zero once it is clinched. For teams outside the playoff spots it is instead the number of
+// this team's losses or the last playoff team's wins that eliminate it. Both ignore ties and the
+// points for tiebreaker.
+//--------------------------------------------------------------------------------------------------
+type TeamPlayoffOdds struct {
+   Roster_id int
+   Owner string
+   Record Record
+   PlayoffProbability float64
+   ByeProbability float64
+   TopSeedProbability float64
+   MagicNumber int
+   InPlayoffPosition bool
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type PlayoffOdds struct {
+   ThroughWeek int
+   Simulations int
+   Seed int64
+   PlayoffTeams int
+   ByeTeams int
+   Teams []TeamPlayoffOdds
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type simulatedTeam struct {
+   mRosterId int
+   mRecord Record
+   mPointsFor float64
+   mMean float64
+   mStdDev float64
+   mWeekMeans map[int]float64
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type simulationCounts struct {
+   mPlayoffs []int
+   mByes []int
+   mTopSeeds []int
+}
+
+//--------------------------------------------------------------------------------------------------
+// GetPlayoffOdds plays out weeks pThroughWeek+1 through pRegularSeasonWeeks many times, drawing each
+// team's weekly score from a normal distribution fitted to its scores so far, and seeds the playoffs
+// the same way as the standings. Division winners get no priority. The remaining weeks' matchups
+// must be loaded, and with a projection blend the projections of every roster's starters too.
+//--------------------------------------------------------------------------------------------------
+func GetPlayoffOdds(pSeasonData SeasonData, pThroughWeek int, pRegularSeasonWeeks int, pOptions PlayoffOddsOptions) (PlayoffOdds, error) {
+
+   league := pSeasonData.mLeagueInfo.mLeague
+
+   var playoffOdds PlayoffOdds
+   playoffOdds.ThroughWeek = pThroughWeek
+   playoffOdds.Simulations = pOptions.Simulations
+   playoffOdds.Seed = pOptions.Seed
+   playoffOdds.PlayoffTeams = min(league.Settings.Playoff_teams, len(pSeasonData.mLeagueInfo.mRosters))
+   playoffOdds.ByeTeams = getNumByeTeams(playoffOdds.PlayoffTeams)
+
+   if playoffOdds.Simulations <= 0 {
+      playoffOdds.Simulations = defaultPlayoffSimulations
+   }
+
+   if playoffOdds.PlayoffTeams <= 0 {
+      return PlayoffOdds{}, errors.New("GetPlayoffOdds: League has no playoff teams")
+   }
+
+   standings, err := GetStandings(pSeasonData, pThroughWeek)
+
+   if err != nil {
+      return PlayoffOdds{}, err
+   }
+
+   teams, err := makeSimulatedTeams(pSeasonData, standings, pThroughWeek, pRegularSeasonWeeks, pOptions.ProjectionBlend)
+
+   if err != nil {
+      return PlayoffOdds{}, err
+   }
+
+   var remainingWeeks [][]Matchup
+
+   for week := pThroughWeek+1 ; week <= pRegularSeasonWeeks ; week++ {
+      weekData, err := pSeasonData.GetWeekData(week, 0)
+
+      if err != nil {
+         return PlayoffOdds{}, fmt.Errorf("GetPlayoffOdds: Week %d: %w", week, err)
+      }
+
+      remainingWeeks = append(remainingWeeks, weekData.mMatchups)
+   }
+
+   counts := runSimulations(teams, remainingWeeks, pThroughWeek, league.HasMedianMatchup(), playoffOdds, pOptions.Parallelism)
+
+   for idx, team := range standings.Teams {
+      var teamOdds TeamPlayoffOdds
+      teamOdds.Roster_id = team.Roster_id
+      teamOdds.Owner = team.Owner
+      teamOdds.Record = team.Record
+      teamOdds.PlayoffProbability = float64(counts.mPlayoffs[idx]) / float64(playoffOdds.Simulations)
+      teamOdds.ByeProbability = float64(counts.mByes[idx]) / float64(playoffOdds.Simulations)
+      teamOdds.TopSeedProbability = float64(counts.mTopSeeds[idx]) / float64(playoffOdds.Simulations)
+
+      playoffOdds.Teams = append(playoffOdds.Teams, teamOdds)
+   }
+
+   setMagicNumbers(playoffOdds.Teams, teams, remainingWeeks, league.HasMedianMatchup(), playoffOdds.PlayoffTeams)
+
+   sort.SliceStable(playoffOdds.Teams, func(i, j int) bool {
+      return playoffOdds.Teams[i].PlayoffProbability > playoffOdds.Teams[j].PlayoffProbability
+   })
+
+   return playoffOdds, nil
+}
+
+//--------------------------------------------------------------------------------------------------
+// LoadRosterProjections loads the projections of every roster's current starters, which a playoff
+// odds projection blend uses for the remaining weeks.
+//--------------------------------------------------------------------------------------------------
+func (seasonData SeasonData) LoadRosterProjections(pContext context.Context) error {
+   var starters []string
+
+   for _, roster := range seasonData.mLeagueInfo.mRosters {
+      for _, starter := range roster.Starters {
+         if starter != EmptyPlayerId {
+            starters = append(starters, starter)
+         }
+      }
+   }
+
+   return seasonData.mProjections.Load(pContext, starters)
+}
+
+//--------------------------------------------------------------------------------------------------
+// The fitted distributions use the league's spread of scores for teams with fewer than two games.
+//--------------------------------------------------------------------------------------------------
+func makeSimulatedTeams(pSeasonData SeasonData, pStandings Standings, pThroughWeek int, pRegularSeasonWeeks int, pProjectionBlend float64) ([]simulatedTeam, error) {
+
+   teamScores := make(map[int][]float64)
+   var leagueScores []float64
+
+   for week := 1 ; week <= pThroughWeek ; week++ {
+      weekData, err := pSeasonData.GetWeekData(week, 0)
+
+      if err != nil {
+         return nil, fmt.Errorf("GetPlayoffOdds: Week %d: %w", week, err)
+      }
+
+      for _, matchup := range getPlayingMatchups(weekData.mMatchups) {
+         teamScores[matchup.Roster_id] = append(teamScores[matchup.Roster_id], matchup.Points)
+         leagueScores = append(leagueScores, matchup.Points)
+      }
+   }
+
+   leagueMean, leagueStdDev := getMeanAndStdDev(leagueScores)
+
+   var teams []simulatedTeam
+
+   for _, teamStanding := range pStandings.Teams {
+
+      var team simulatedTeam
+      team.mRosterId = teamStanding.Roster_id
+      team.mRecord = teamStanding.Record
+      team.mPointsFor = teamStanding.PointsFor
+      team.mMean, team.mStdDev = leagueMean, leagueStdDev
+      team.mWeekMeans = make(map[int]float64)
+
+      if scores := teamScores[team.mRosterId] ; len(scores) >= 2 {
+         team.mMean, team.mStdDev = getMeanAndStdDev(scores)
+      } else if len(scores) == 1 {
+         team.mMean = scores[0]
+      }
+
+      for week := pThroughWeek+1 ; week <= pRegularSeasonWeeks ; week++ {
+         team.mWeekMeans[week] = team.mMean
+
+         if pProjectionBlend <= 0.0 {
+            continue
+         }
+
+         if projectedPoints, err := getProjectedTeamPoints(pSeasonData, team.mRosterId, week) ; err == nil {
+            team.mWeekMeans[week] = (1.0 - pProjectionBlend) * team.mMean + pProjectionBlend * projectedPoints
+         }
+      }
+
+      teams = append(teams, team)
+   }
+
+   return teams, nil
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func getProjectedTeamPoints(pSeasonData SeasonData, pRosterId int, pWeek int) (float64, error) {
+
+   roster, hasRoster := pSeasonData.mLeagueInfo.GetRoster(pRosterId)
+
+   if !hasRoster {
+      return 0.0, ErrNotFound
+   }
+
+   projectedPoints := 0.0
+
+   for _, starter := range roster.Starters {
+      if starter == EmptyPlayerId {
+         continue
+      }
+
+      starterProjection, err := pSeasonData.mProjections.GetWeekScore(starter, pWeek, pSeasonData.mLeagueInfo.mLeague.Scoring_settings)
+
+      if err != nil {
+         return 0.0, err
+      }
+
+      projectedPoints += starterProjection
+   }
+
+   return projectedPoints, nil
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func runSimulations(pTeams []simulatedTeam, pRemainingWeeks [][]Matchup, pThroughWeek int, pHasMedian bool, pPlayoffOdds PlayoffOdds, pParallelism int) simulationCounts {
+
+   numChunks := (pPlayoffOdds.Simulations + simulationChunkSize - 1) / simulationChunkSize
+   parallelism := pParallelism
+
+   if parallelism <= 0 {
+      parallelism = runtime.NumCPU()
+   }
+
+   var counts simulationCounts
+   counts.mPlayoffs = make([]int, len(pTeams))
+   counts.mByes = make([]int, len(pTeams))
+   counts.mTopSeeds = make([]int, len(pTeams))
+
+   teamIdxs := make(map[int]int)
+
+   for idx, team := range pTeams {
+      teamIdxs[team.mRosterId] = idx
+   }
+
+   chunks := make(chan int)
+   var mutex sync.Mutex
+   var waitGroup sync.WaitGroup
+
+   for worker := 0 ; worker < min(parallelism, numChunks) ; worker++ {
+      waitGroup.Add(1)
+
+      go func() {
+         defer waitGroup.Done()
+
+         for chunk := range chunks {
+            random := rand.New(rand.NewSource(pPlayoffOdds.Seed + int64(chunk)))
+            numSimulations := min(simulationChunkSize, pPlayoffOdds.Simulations - chunk * simulationChunkSize)
+            chunkCounts := simulateChunk(pTeams, teamIdxs, pRemainingWeeks, pThroughWeek, pHasMedian, pPlayoffOdds, numSimulations, random)
+
+            mutex.Lock()
+
+            for idx := range pTeams {
+               counts.mPlayoffs[idx] += chunkCounts.mPlayoffs[idx]
+               counts.mByes[idx] += chunkCounts.mByes[idx]
+               counts.mTopSeeds[idx] += chunkCounts.mTopSeeds[idx]
+            }
+
+            mutex.Unlock()
+         }
+      }()
+   }
+
+   for chunk := 0 ; chunk < numChunks ; chunk++ {
+      chunks <- chunk
+   }
+
+   close(chunks)
+   waitGroup.Wait()
+
+   return counts
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func simulateChunk(pTeams []simulatedTeam, pTeamIdxs map[int]int, pRemainingWeeks [][]Matchup, pThroughWeek int, pHasMedian bool, pPlayoffOdds PlayoffOdds, pNumSimulations int, pRandom *rand.Rand) simulationCounts {
+
+   var counts simulationCounts
+   counts.mPlayoffs = make([]int, len(pTeams))
+   counts.mByes = make([]int, len(pTeams))
+   counts.mTopSeeds = make([]int, len(pTeams))
+
+   records := make([]Record, len(pTeams))
+   pointsFor := make([]float64, len(pTeams))
+   weekPoints := make([]float64, len(pTeams))
+   seeds := make([]int, len(pTeams))
+
+   for simulation := 0 ; simulation < pNumSimulations ; simulation++ {
+
+      for idx, team := range pTeams {
+         records[idx] = team.mRecord
+         pointsFor[idx] = team.mPointsFor
+      }
+
+      for weekIdx, weekMatchups := range pRemainingWeeks {
+
+         week := pThroughWeek + 1 + weekIdx
+         var playingIdxs []int
+
+         for _, matchup := range getPlayingMatchups(weekMatchups) {
+            if teamIdx, hasTeam := pTeamIdxs[matchup.Roster_id] ; hasTeam {
+               team := pTeams[teamIdx]
+               weekPoints[teamIdx] = team.mWeekMeans[week] + pRandom.NormFloat64() * team.mStdDev
+               pointsFor[teamIdx] += weekPoints[teamIdx]
+               playingIdxs = append(playingIdxs, teamIdx)
+            }
+         }
+
+         for _, matchup := range getPlayingMatchups(weekMatchups) {
+            opponentMatchup, err := GetMatchupOpponentRoster(weekMatchups, matchup.Roster_id)
+            teamIdx, hasTeam := pTeamIdxs[matchup.Roster_id]
+            opponentIdx, hasOpponent := pTeamIdxs[opponentMatchup.Roster_id]
+
+            if err == nil && hasTeam && hasOpponent {
+               records[teamIdx].Add(compareMatchupPoints(weekPoints[teamIdx], weekPoints[opponentIdx]))
+            }
+         }
+
+         if pHasMedian && len(playingIdxs) > 0 {
+            var playingPoints []float64
+
+            for _, teamIdx := range playingIdxs {
+               playingPoints = append(playingPoints, weekPoints[teamIdx])
+            }
+
+            medianPoints := getMedian(playingPoints)
+
+            for _, teamIdx := range playingIdxs {
+               records[teamIdx].Add(compareMatchupPoints(weekPoints[teamIdx], medianPoints))
+            }
+         }
+      }
+
+      for idx := range seeds {
+         seeds[idx] = idx
+      }
+
+      sort.SliceStable(seeds, func(i, j int) bool {
+         lhs, rhs := seeds[i], seeds[j]
+
+         if records[lhs].GetWinPercentage() != records[rhs].GetWinPercentage() {
+            return records[lhs].GetWinPercentage() > records[rhs].GetWinPercentage()
+         }
+
+         return pointsFor[lhs] > pointsFor[rhs]
+      })
+
+      for seed, teamIdx := range seeds {
+         if seed < pPlayoffOdds.PlayoffTeams {
+            counts.mPlayoffs[teamIdx]++
+         }
+
+         if seed < pPlayoffOdds.ByeTeams {
+            counts.mByes[teamIdx]++
+         }
+      }
+
+      counts.mTopSeeds[seeds[0]]++
+   }
+
+   return counts
+}
+
+//--------------------------------------------------------------------------------------------------
+// pOdds and pTeams must both be in standings order.
+//--------------------------------------------------------------------------------------------------
+func setMagicNumbers(pOdds []TeamPlayoffOdds, pTeams []simulatedTeam, pRemainingWeeks [][]Matchup, pHasMedian bool, pPlayoffTeams int) {
+
+   remainingGames := make(map[int]int)
+
+   for _, weekMatchups := range pRemainingWeeks {
+      for _, matchup := range getPlayingMatchups(weekMatchups) {
+         if _, err := GetMatchupOpponentRoster(weekMatchups, matchup.Roster_id) ; err == nil {
+            remainingGames[matchup.Roster_id]++
+         }
+
+         if pHasMedian {
+            remainingGames[matchup.Roster_id]++
+         }
+      }
+   }
+
+   if pPlayoffTeams >= len(pTeams) {
+      return
+   }
+
+   lastIn := pTeams[pPlayoffTeams-1]
+   maxChallengerWins := 0
+
+   for _, team := range pTeams[pPlayoffTeams:] {
+      maxChallengerWins = max(maxChallengerWins, team.mRecord.Wins + remainingGames[team.mRosterId])
+   }
+
+   for idx := range pOdds {
+      team := pTeams[idx]
+      pOdds[idx].InPlayoffPosition = idx < pPlayoffTeams
+
+      if pOdds[idx].InPlayoffPosition {
+         pOdds[idx].MagicNumber = max(0, maxChallengerWins + 1 - team.mRecord.Wins)
+      } else {
+         pOdds[idx].MagicNumber = max(0, team.mRecord.Wins + remainingGames[team.mRosterId] + 1 - lastIn.mRecord.Wins)
+      }
+   }
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (playoffOdds PlayoffOdds) Print() {
+
+   log.Printf("Playoff Odds After Week %d (%d Simulations, Seed %d)", playoffOdds.ThroughWeek, playoffOdds.Simulations, playoffOdds.Seed)
+   log.Printf("   %-16s %-8s %8s %8s %8s %s", "Owner", "Record", "Playoffs", "Bye", "#1 Seed", "Magic Number")
+
+   for _, team := range playoffOdds.Teams {
+
+      magicNumber := fmt.Sprintf("%d", team.MagicNumber)
+
+      switch {
+      case team.InPlayoffPosition && team.MagicNumber == 0:
+         magicNumber = "Clinched"
+      case !team.InPlayoffPosition && team.MagicNumber == 0:
+         magicNumber = "Eliminated"
+      case !team.InPlayoffPosition:
+         magicNumber += " (Elimination)"
+      }
+
+      log.Printf("   %-16s %-8s %7.1f%% %7.1f%% %7.1f%% %s", team.Owner, team.Record, team.PlayoffProbability * 100.0, team.ByeProbability * 100.0, team.TopSeedProbability * 100.0, magicNumber)
+   }
+}
+
+//--------------------------------------------------------------------------------------------------
+// Brackets are filled out to the next power of two teams, with the top seeds getting byes.
+//--------------------------------------------------------------------------------------------------
+func getNumByeTeams(pPlayoffTeams int) int {
+   bracketTeams := 1
+
+   for bracketTeams < pPlayoffTeams {
+      bracketTeams *= 2
+   }
+
+   return bracketTeams - pPlayoffTeams
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func getMeanAndStdDev(pValues []float64) (float64, float64) {
+
+   mean := getAverage(pValues)
+
+   if len(pValues) < 2 {
+      return mean, 0.0
+   }
+
+   sumSquares := 0.0
+
+   for _, value := range pValues {
+      sumSquares += (value - mean) * (value - mean)
+   }
+
+   return mean, math.Sqrt(sumSquares / float64(len(pValues)-1))
+}
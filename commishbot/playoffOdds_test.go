@@ -0,0 +1,58 @@
+package main
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func TestPlayoffOdds(pTest *testing.T) {
+
+   _, seasonData := newTestSeason(pTest, makeDefaultTestSchedule(pTest))
+
+   options := PlayoffOddsOptions{Simulations: 2000, Seed: 7, Parallelism: 1}
+   playoffOdds, err := GetPlayoffOdds(seasonData, 10, 14, options)
+
+   if err != nil {
+      pTest.Fatalf("GetPlayoffOdds: %s", err.Error())
+   }
+
+   if playoffOdds.PlayoffTeams != 6 || playoffOdds.ByeTeams != 2 {
+      pTest.Errorf("%d playoff teams and %d byes, expected 6 and 2", playoffOdds.PlayoffTeams, playoffOdds.ByeTeams)
+   }
+
+   playoffTotal, byeTotal, topSeedTotal := 0.0, 0.0, 0.0
+
+   for _, team := range playoffOdds.Teams {
+      if team.TopSeedProbability > team.ByeProbability || team.ByeProbability > team.PlayoffProbability || team.PlayoffProbability > 1.0 {
+         pTest.Errorf("%s: Playoff %f, bye %f and #1 seed %f probabilities are inconsistent", team.Owner, team.PlayoffProbability, team.ByeProbability, team.TopSeedProbability)
+      }
+
+      playoffTotal += team.PlayoffProbability
+      byeTotal += team.ByeProbability
+      topSeedTotal += team.TopSeedProbability
+   }
+
+   if math.Abs(playoffTotal - 6.0) > 1e-9 || math.Abs(byeTotal - 2.0) > 1e-9 || math.Abs(topSeedTotal - 1.0) > 1e-9 {
+      pTest.Errorf("Probabilities sum to %f, %f and %f, expected 6, 2 and 1", playoffTotal, byeTotal, topSeedTotal)
+   }
+
+   // The same seed gives the same odds however many workers run the simulations
+   options.Parallelism = 4
+   parallelOdds, _ := GetPlayoffOdds(seasonData, 10, 14, options)
+
+   if !slices.Equal(parallelOdds.Teams, playoffOdds.Teams) {
+      pTest.Errorf("Odds with 4 workers %+v differ from 1 worker %+v", parallelOdds.Teams, playoffOdds.Teams)
+   }
+
+   // With four weeks left the Falcons (2-8) can still reach the Bandits' four wins, but were never
+   // simulated into the playoffs
+   falcons := playoffOdds.Teams[len(playoffOdds.Teams)-1]
+
+   if falcons.Owner != "Falcons" || falcons.InPlayoffPosition || falcons.MagicNumber != 3 {
+      pTest.Errorf("Last team %+v, expected the Falcons with an elimination number of 3", falcons)
+   }
+}
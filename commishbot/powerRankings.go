@@ -0,0 +1,332 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+)
+
+// The recent form component looks at this many of the latest weeks.
+const recentFormWeeks = 3
+
+//--------------------------------------------------------------------------------------------------
+// PowerRankingWeights sets how much each component counts toward the power score. AllPlay is the
+// season all-play win percentage, Record the head to head win percentage, Points the points per
+// game relative to the league's best and Recent the all-play win percentage over the latest weeks.
+//--------------------------------------------------------------------------------------------------
+type PowerRankingWeights struct {
+   AllPlay float64
+   Record float64
+   Points float64
+   Recent float64
+}
+
+var DefaultPowerRankingWeights = PowerRankingWeights{0.4, 0.3, 0.2, 0.1}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (weights PowerRankingWeights) validate() error {
+
+   if weights.AllPlay < 0.0 || weights.Record < 0.0 || weights.Points < 0.0 || weights.Recent < 0.0 {
+      return errors.New("power ranking weights cannot be negative")
+   }
+
+   if weights.AllPlay + weights.Record + weights.Points + weights.Recent <= 0.0 {
+      return errors.New("at least one power ranking weight must be positive")
+   }
+
+   return nil
+}
+
+//--------------------------------------------------------------------------------------------------
+// Luck is the number of head to head wins above the wins expected from the team's weekly all-play
+// win percentages. Strength of schedule is the average all-play win percentage of the opponents
+// already played or still to play.
+//--------------------------------------------------------------------------------------------------
+type TeamPowerRanking struct {
+   Rank int
+   Movement int
+   HasMovement bool
+   Roster_id int
+   Owner string
+   PowerScore float64
+   Wins float64
+   ExpectedWins float64
+   Luck float64
+   PastStrengthOfSchedule float64
+   RemainingStrengthOfSchedule float64
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type PowerRankings struct {
+   Week int
+   Teams []TeamPowerRanking
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type teamWeekStats struct {
+   mPoints float64
+   mAllPlayPercentage float64
+   mOpponentRosterId int
+   mResult MatchupResult
+   mHasOpponent bool
+}
+
+//--------------------------------------------------------------------------------------------------
+// GetPowerRankings ranks the teams on weeks 1 through pWeek and compares each team's rank with the
+// previous week's. Remaining strength of schedule uses the pairings of weeks pWeek+1 through
+// pRegularSeasonWeeks, so those weeks' matchups must be loaded too.
+//--------------------------------------------------------------------------------------------------
+func GetPowerRankings(pSeasonData SeasonData, pWeek int, pRegularSeasonWeeks int, pWeights PowerRankingWeights) (PowerRankings, error) {
+
+   weekStats, err := getTeamWeekStats(pSeasonData, pRegularSeasonWeeks)
+
+   if err != nil {
+      return PowerRankings{}, err
+   }
+
+   powerRankings := rankTeams(pSeasonData, weekStats, pWeek, pRegularSeasonWeeks, pWeights)
+
+   if pWeek > 1 {
+      previousRanks := make(map[int]int)
+
+      for _, team := range rankTeams(pSeasonData, weekStats, pWeek-1, pRegularSeasonWeeks, pWeights).Teams {
+         previousRanks[team.Roster_id] = team.Rank
+      }
+
+      for idx := range powerRankings.Teams {
+         team := &powerRankings.Teams[idx]
+         team.Movement = previousRanks[team.Roster_id] - team.Rank
+         team.HasMovement = true
+      }
+   }
+
+   return powerRankings, nil
+}
+
+//--------------------------------------------------------------------------------------------------
+// getTeamWeekStats collects each team's points, all-play win percentage and opponent for every
+// regular season week, keyed by roster id and then week. Teams on a bye are left out of that week.
+//--------------------------------------------------------------------------------------------------
+func getTeamWeekStats(pSeasonData SeasonData, pRegularSeasonWeeks int) (map[int]map[int]teamWeekStats, error) {
+
+   weekStats := make(map[int]map[int]teamWeekStats)
+
+   for _, roster := range pSeasonData.mLeagueInfo.mRosters {
+      weekStats[roster.Roster_id] = make(map[int]teamWeekStats)
+   }
+
+   for week := 1 ; week <= pRegularSeasonWeeks ; week++ {
+
+      weekData, err := pSeasonData.GetWeekData(week, 0)
+
+      if err != nil {
+         return nil, fmt.Errorf("GetPowerRankings: Week %d: %w", week, err)
+      }
+
+      weekMatchups := getPlayingMatchups(weekData.mMatchups)
+
+      for _, matchup := range weekMatchups {
+
+         var stats teamWeekStats
+         stats.mPoints = matchup.Points
+
+         var allPlayRecord Record
+
+         for _, otherMatchup := range weekMatchups {
+            if otherMatchup.Roster_id != matchup.Roster_id {
+               allPlayRecord.Add(compareMatchupPoints(matchup.Points, otherMatchup.Points))
+            }
+         }
+
+         stats.mAllPlayPercentage = allPlayRecord.GetWinPercentage()
+
+         opponentMatchup, err := GetMatchupOpponentRoster(weekData.mMatchups, matchup.Roster_id)
+
+         if err != nil && !errors.Is(err, ErrNoOpponent) {
+            return nil, fmt.Errorf("GetPowerRankings: Week %d: %w", week, err)
+         }
+
+         if err == nil {
+            stats.mOpponentRosterId = opponentMatchup.Roster_id
+            stats.mResult = compareMatchupPoints(matchup.Points, opponentMatchup.Points)
+            stats.mHasOpponent = true
+         }
+
+         if teamStats, hasTeam := weekStats[matchup.Roster_id] ; hasTeam {
+            teamStats[week] = stats
+         }
+      }
+   }
+
+   return weekStats, nil
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func rankTeams(pSeasonData SeasonData, pWeekStats map[int]map[int]teamWeekStats, pWeek int, pRegularSeasonWeeks int, pWeights PowerRankingWeights) PowerRankings {
+
+   var powerRankings PowerRankings
+   powerRankings.Week = pWeek
+
+   allPlayPercentages := make(map[int]float64)
+   pointsPerGame := make(map[int]float64)
+   maxPointsPerGame := 0.0
+
+   for rosterId, teamStats := range pWeekStats {
+      allPlayPercentages[rosterId] = getAverageAllPlayPercentage(teamStats, 1, pWeek)
+      pointsPerGame[rosterId] = getPointsPerGame(teamStats, pWeek)
+      maxPointsPerGame = max(maxPointsPerGame, pointsPerGame[rosterId])
+   }
+
+   totalWeight := pWeights.AllPlay + pWeights.Record + pWeights.Points + pWeights.Recent
+
+   for _, roster := range pSeasonData.mLeagueInfo.mRosters {
+
+      teamStats := pWeekStats[roster.Roster_id]
+
+      var team TeamPowerRanking
+      team.Roster_id = roster.Roster_id
+      team.Owner = pSeasonData.mLeagueInfo.mDisplayNames[roster.Owner_id]
+
+      var record Record
+      var pastOpponentPercentages []float64
+      var remainingOpponentPercentages []float64
+
+      for week := 1 ; week <= pRegularSeasonWeeks ; week++ {
+
+         stats, hasStats := teamStats[week]
+
+         if !hasStats || !stats.mHasOpponent {
+            continue
+         }
+
+         if week > pWeek {
+            remainingOpponentPercentages = append(remainingOpponentPercentages, allPlayPercentages[stats.mOpponentRosterId])
+            continue
+         }
+
+         record.Add(stats.mResult)
+         team.ExpectedWins += stats.mAllPlayPercentage
+         pastOpponentPercentages = append(pastOpponentPercentages, allPlayPercentages[stats.mOpponentRosterId])
+      }
+
+      team.Wins = float64(record.Wins) + 0.5 * float64(record.Ties)
+      team.Luck = team.Wins - team.ExpectedWins
+      team.PastStrengthOfSchedule = getAverage(pastOpponentPercentages)
+      team.RemainingStrengthOfSchedule = getAverage(remainingOpponentPercentages)
+
+      pointsShare := 0.0
+
+      if maxPointsPerGame > 0.0 {
+         pointsShare = pointsPerGame[roster.Roster_id] / maxPointsPerGame
+      }
+
+      if totalWeight > 0.0 {
+         weightedScore := pWeights.AllPlay * allPlayPercentages[roster.Roster_id]
+         weightedScore += pWeights.Record * record.GetWinPercentage()
+         weightedScore += pWeights.Points * pointsShare
+         weightedScore += pWeights.Recent * getAverageAllPlayPercentage(teamStats, pWeek - recentFormWeeks + 1, pWeek)
+
+         team.PowerScore = weightedScore / totalWeight * 100.0
+      }
+
+      powerRankings.Teams = append(powerRankings.Teams, team)
+   }
+
+   sort.SliceStable(powerRankings.Teams, func(i, j int) bool {
+      return compareScores(powerRankings.Teams[i].PowerScore, powerRankings.Teams[j].PowerScore, SortDescending) < 0
+   })
+
+   for idx := range powerRankings.Teams {
+      powerRankings.Teams[idx].Rank = idx+1
+   }
+
+   return powerRankings
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (powerRankings PowerRankings) Print() {
+
+   log.Printf("Power Rankings After Week %d", powerRankings.Week)
+   log.Printf("   %-4s %-4s %-16s %6s %5s %5s %6s %5s %5s", "Rank", "Move", "Owner", "Power", "W", "xW", "Luck", "SoS", "rSoS")
+
+   for _, team := range powerRankings.Teams {
+      log.Printf("   %-4d %-4s %-16s %6.2f %5.1f %5.2f %+6.2f %5.3f %5.3f", team.Rank, team.FormatMovement(), team.Owner, team.PowerScore, team.Wins, team.ExpectedWins, team.Luck, team.PastStrengthOfSchedule, team.RemainingStrengthOfSchedule)
+   }
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (team TeamPowerRanking) FormatMovement() string {
+
+   switch {
+   case !team.HasMovement:
+      return "-"
+   case team.Movement > 0:
+      return "▲" + strconv.Itoa(team.Movement)
+   case team.Movement < 0:
+      return "▼" + strconv.Itoa(-team.Movement)
+   }
+
+   return "="
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func getAverageAllPlayPercentage(pTeamStats map[int]teamWeekStats, pFirstWeek int, pLastWeek int) float64 {
+   var allPlayPercentages []float64
+
+   for week := max(1, pFirstWeek) ; week <= pLastWeek ; week++ {
+      if stats, hasStats := pTeamStats[week] ; hasStats {
+         allPlayPercentages = append(allPlayPercentages, stats.mAllPlayPercentage)
+      }
+   }
+
+   return getAverage(allPlayPercentages)
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func getPointsPerGame(pTeamStats map[int]teamWeekStats, pLastWeek int) float64 {
+   var points []float64
+
+   for week := 1 ; week <= pLastWeek ; week++ {
+      if stats, hasStats := pTeamStats[week] ; hasStats {
+         points = append(points, stats.mPoints)
+      }
+   }
+
+   return getAverage(points)
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func getAverage(pValues []float64) float64 {
+
+   if len(pValues) == 0 {
+      return 0.0
+   }
+
+   total := 0.0
+
+   for _, value := range pValues {
+      total += value
+   }
+
+   return total / float64(len(pValues))
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func TestPowerRankings(pTest *testing.T) {
+
+   _, seasonData := newTestSeason(pTest, makeDefaultTestSchedule(pTest))
+
+   powerRankings, err := GetPowerRankings(seasonData, 14, 14, DefaultPowerRankingWeights)
+
+   if err != nil {
+      pTest.Fatalf("GetPowerRankings: %s", err.Error())
+   }
+
+   expectedOwners := []string{"Jaguars", "Giants", "Icemen", "Hornets", "Aces", "Bandits", "Dragons", "Eagles", "Cobras", "Falcons"}
+   expectedMovements := []string{"=", "=", "▲2", "▼1", "▼1", "▲2", "▼1", "▼1", "=", "="}
+
+   var owners []string
+   var movements []string
+
+   for _, team := range powerRankings.Teams {
+      owners = append(owners, team.Owner)
+      movements = append(movements, team.FormatMovement())
+   }
+
+   if !slices.Equal(owners, expectedOwners) || !slices.Equal(movements, expectedMovements) {
+      pTest.Errorf("Rankings %v %v, expected %v %v", owners, movements, expectedOwners, expectedMovements)
+   }
+
+   // The Jaguars won 9 games against 7.33 expected from their weekly all-play records
+   jaguars := powerRankings.Teams[0]
+
+   if math.Abs(jaguars.Luck - 1.67) > 0.005 || math.Abs(jaguars.PastStrengthOfSchedule - 0.482) > 0.0005 || jaguars.RemainingStrengthOfSchedule != 0.0 {
+      pTest.Errorf("Jaguars luck %f, strength of schedule %f and %f, expected 1.67, 0.482 and 0", jaguars.Luck, jaguars.PastStrengthOfSchedule, jaguars.RemainingStrengthOfSchedule)
+   }
+
+   powerRankings, _ = GetPowerRankings(seasonData, 10, 14, DefaultPowerRankingWeights)
+
+   for _, team := range powerRankings.Teams {
+      if team.RemainingStrengthOfSchedule <= 0.0 {
+         pTest.Errorf("Week 10 %s: Remaining strength of schedule %f, expected the last four weeks' opponents", team.Owner, team.RemainingStrengthOfSchedule)
+      }
+   }
+
+   // Weighting only points ranks the teams by points per game played, which puts the Jaguars and
+   // their week 5 bye ahead of the Giants' higher points total
+   powerRankings, _ = GetPowerRankings(seasonData, 14, 14, PowerRankingWeights{Points: 1.0})
+
+   if owners := []string{powerRankings.Teams[0].Owner, powerRankings.Teams[1].Owner} ; !slices.Equal(owners, []string{"Jaguars", "Giants"}) {
+      pTest.Errorf("Points only leaders %v, expected [Jaguars Giants]", owners)
+   }
+}
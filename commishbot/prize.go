@@ -0,0 +1,173 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+)
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type PrizeData int
+
+const (
+   PrizeDataPlayers PrizeData = 1 << iota
+   PrizeDataPlayerStats
+   PrizeDataProjections
+   PrizeDataBrackets
+)
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type SortOrder int
+
+const (
+   SortAscending SortOrder = iota
+   SortDescending
+)
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type Prize interface {
+   Name() string
+   Criteria() string
+   RequiredData() PrizeData
+   Score(pWeekData WeekData, pMatchup Matchup) (PrizeEntry, error)
+   SortOrder() SortOrder
+   TieBreakers() []TieBreaker
+
+   // Params returns the values a configurable prize was configured with, defaults included
+   Params() map[string]any
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type ConfigurablePrize interface {
+   Prize
+   Configure(pParams map[string]json.RawMessage) (Prize, error)
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type PrizeInfo struct {
+   mName string
+   mCriteria string
+   mRequiredData PrizeData
+   mSortOrder SortOrder
+   mTieBreakers []TieBreaker
+}
+
+var prizeRegistry = make(map[string]Prize)
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (prizeInfo PrizeInfo) Name() string {
+   return prizeInfo.mName
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (prizeInfo PrizeInfo) Criteria() string {
+   return prizeInfo.mCriteria
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (prizeInfo PrizeInfo) RequiredData() PrizeData {
+   return prizeInfo.mRequiredData
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (prizeInfo PrizeInfo) SortOrder() SortOrder {
+   return prizeInfo.mSortOrder
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (prizeInfo PrizeInfo) TieBreakers() []TieBreaker {
+   return prizeInfo.mTieBreakers
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (prizeInfo PrizeInfo) Params() map[string]any {
+   return nil
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func RegisterPrize(pPrize Prize) {
+   prizeKey := makePrizeKey(pPrize.Name())
+
+   if _, hasKey := prizeRegistry[prizeKey] ; hasKey {
+      panic("RegisterPrize: Prize " + pPrize.Name() + " is already registered")
+   }
+
+   prizeRegistry[prizeKey] = pPrize
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func GetPrize(pName string) (Prize, bool) {
+   prize, hasKey := prizeRegistry[makePrizeKey(pName)]
+   return prize, hasKey
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func GetPrizes() []Prize {
+   var prizes []Prize
+
+   for _, prize := range prizeRegistry {
+      prizes = append(prizes, prize)
+   }
+
+   sort.Slice(prizes, func(i, j int) bool {
+      return prizes[i].Name() < prizes[j].Name()
+   })
+
+   return prizes
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func ConfigurePrize(pPrize Prize, pParams map[string]json.RawMessage) (Prize, error) {
+
+   configurablePrize, isConfigurable := pPrize.(ConfigurablePrize)
+
+   if !isConfigurable {
+      if len(pParams) > 0 {
+         return nil, errors.New("prize does not accept parameters")
+      }
+
+      return pPrize, nil
+   }
+
+   return configurablePrize.Configure(pParams)
+}
+
+//--------------------------------------------------------------------------------------------------
+// Prize names are matched ignoring case, spaces, dashes and underscores so that "Hot Start",
+// "hot_start" and "HotStart" all refer to the same prize.
+//--------------------------------------------------------------------------------------------------
+func makePrizeKey(pName string) string {
+   replacer := strings.NewReplacer(" ", "", "-", "", "_", "")
+   return strings.ToLower(replacer.Replace(pName))
+}
@@ -1,11 +1,44 @@
 package main
 
+import (
+	"math"
+	"sort"
+)
+
+// Scores are sums of fractional points, so treat values this close together as a tie.
+const scoreTolerance = 1e-9
+
+const ReasonNoOpponent = "No Opponent This Week"
+const ReasonWonMatchup = "Won Matchup"
+const ReasonLostMatchup = "Lost Matchup"
+const ReasonTiedMatchup = "Tied Matchup"
+const ReasonNoStarters = "No Starting Players"
+const ReasonNotPlayoffWeek = "Not A Playoff Week"
+const ReasonNotEliminated = "Not Eliminated This Week"
+
 //--------------------------------------------------------------------------------------------------
 //
 //--------------------------------------------------------------------------------------------------
+type Eligibility int
+
+const (
+   Eligible Eligibility = iota
+   Ineligible
+)
+
+//--------------------------------------------------------------------------------------------------
+// Only eligible entries are ranked. Reason explains why an ineligible team does not qualify, and the
+// score of an ineligible entry is meaningless.
+//--------------------------------------------------------------------------------------------------
 type PrizeEntry struct {
    Score float64
    Owner string
+   Roster_id int
+   Rank int
+   Eligibility Eligibility
+   Reason string
+   Evidence PrizeEvidence
+   TieBreakerValues []float64
 }
 
 type PrizeEntries []PrizeEntry
@@ -13,30 +46,117 @@ type PrizeEntries []PrizeEntry
 //--------------------------------------------------------------------------------------------------
 //
 //--------------------------------------------------------------------------------------------------
-func (prizeEntries PrizeEntries) Len() int {
-   return len(prizeEntries)
+func MakeIneligibleEntry(pReason string) PrizeEntry {
+   return PrizeEntry{Eligibility: Ineligible, Reason: pReason}
+}
+
+//--------------------------------------------------------------------------------------------------
+// Rank orders the entries by score and then by each tie-breaker in turn. Entries that are still level
+// after every tie-breaker share a rank, and the next rank is skipped for each extra entry in the tie.
+// Entries that tie on everything keep their original order. The name of the tie-breaker that
+// separated the winner from the runner up is returned, or an empty string if the scores alone
+// decided it or the top entries could not be separated.
+//--------------------------------------------------------------------------------------------------
+func (prizeEntries PrizeEntries) Rank(pSortOrder SortOrder, pTieBreakers []TieBreaker) string {
+
+   sort.SliceStable(prizeEntries, func(i, j int) bool {
+      return comparePrizeEntries(prizeEntries[i], prizeEntries[j], pSortOrder, pTieBreakers) < 0
+   })
+
+   for idx := range prizeEntries {
+      if idx > 0 && comparePrizeEntries(prizeEntries[idx-1], prizeEntries[idx], pSortOrder, pTieBreakers) == 0 {
+         prizeEntries[idx].Rank = prizeEntries[idx-1].Rank
+      } else {
+         prizeEntries[idx].Rank = idx+1
+      }
+   }
+
+   if len(prizeEntries) < 2 || compareScores(prizeEntries[0].Score, prizeEntries[1].Score, pSortOrder) != 0 {
+      return ""
+   }
+
+   for idx, tieBreaker := range pTieBreakers {
+      if compareScores(prizeEntries[0].TieBreakerValues[idx], prizeEntries[1].TieBreakerValues[idx], tieBreaker.SortOrder) != 0 {
+         return tieBreaker.Name
+      }
+   }
+
+   return ""
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (prizeEntries PrizeEntries) Winners() PrizeEntries {
+   var winners PrizeEntries
+
+   for _, prizeEntry := range prizeEntries {
+      if prizeEntry.Rank == 1 {
+         winners = append(winners, prizeEntry)
+      }
+   }
+
+   return winners
 }
 
 //--------------------------------------------------------------------------------------------------
 //
 //--------------------------------------------------------------------------------------------------
-func (prizeEntries PrizeEntries) Less(i, j int) bool {
-   return prizeEntries[i].Score < prizeEntries[j].Score
+func getOwners(pPrizeEntries PrizeEntries) []string {
+   var owners []string
+
+   for _, prizeEntry := range pPrizeEntries {
+      owners = append(owners, prizeEntry.Owner)
+   }
+
+   return owners
 }
 
 //--------------------------------------------------------------------------------------------------
 //
 //--------------------------------------------------------------------------------------------------
-func (prizeEntries PrizeEntries) Swap(i, j int) {
-   prizeEntries[i], prizeEntries[j] = prizeEntries[j], prizeEntries[i]
+func comparePrizeEntries(pLhs PrizeEntry, pRhs PrizeEntry, pSortOrder SortOrder, pTieBreakers []TieBreaker) int {
+
+   if result := compareScores(pLhs.Score, pRhs.Score, pSortOrder) ; result != 0 {
+      return result
+   }
+
+   for idx, tieBreaker := range pTieBreakers {
+      if result := compareScores(pLhs.TieBreakerValues[idx], pRhs.TieBreakerValues[idx], tieBreaker.SortOrder) ; result != 0 {
+         return result
+      }
+   }
+
+   return 0
+}
+
+//--------------------------------------------------------------------------------------------------
+// compareScores returns a negative number when pLhs ranks ahead of pRhs.
+//--------------------------------------------------------------------------------------------------
+func compareScores(pLhs float64, pRhs float64, pSortOrder SortOrder) int {
+
+   if pLhs == pRhs || math.Abs(pLhs - pRhs) < scoreTolerance {
+      return 0
+   }
+
+   if (pLhs > pRhs) == (pSortOrder == SortDescending) {
+      return -1
+   }
+
+   return 1
 }
 
 //--------------------------------------------------------------------------------------------------
 //
 //--------------------------------------------------------------------------------------------------
-func (prizeEntries PrizeEntries) Reverse() {
-   for i := len(prizeEntries)/2-1; i >= 0; i-- {
-      opp := len(prizeEntries)-1-i
-      prizeEntries[i], prizeEntries[opp] = prizeEntries[opp], prizeEntries[i]
+func (prizeEntries PrizeEntries) isTied(pRank int) bool {
+   numEntries := 0
+
+   for _, prizeEntry := range prizeEntries {
+      if prizeEntry.Rank == pRank {
+         numEntries++
+      }
    }
+
+   return numEntries > 1
 }
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func TestRankPrizeEntries(pTest *testing.T) {
+
+   tieBreakers := []TieBreaker{TieBreakerBenchPoints, TieBreakerFewestMoves}
+
+   prizeEntries := PrizeEntries{
+      {Score: 90.0, Owner: "Aces", TieBreakerValues: []float64{20.0, 3.0}},
+      {Score: 100.0, Owner: "Bandits", TieBreakerValues: []float64{30.0, 5.0}},
+      {Score: 100.0, Owner: "Cobras", TieBreakerValues: []float64{30.0, 2.0}},
+      {Score: 80.0, Owner: "Dragons", TieBreakerValues: []float64{10.0, 1.0}},
+      {Score: 80.0, Owner: "Eagles", TieBreakerValues: []float64{10.0, 1.0}},
+      {Score: 70.0, Owner: "Falcons", TieBreakerValues: []float64{40.0, 0.0}},
+   }
+
+   decidingTieBreaker := prizeEntries.Rank(SortDescending, tieBreakers)
+
+   if decidingTieBreaker != TieBreakerFewestMoves.Name {
+      pTest.Errorf("Deciding tie-breaker %q, expected %q", decidingTieBreaker, TieBreakerFewestMoves.Name)
+   }
+
+   expectedOwners := []string{"Cobras", "Bandits", "Aces", "Dragons", "Eagles", "Falcons"}
+   expectedRanks := []int{1, 2, 3, 4, 4, 6}
+
+   var ranks []int
+
+   for _, prizeEntry := range prizeEntries {
+      ranks = append(ranks, prizeEntry.Rank)
+   }
+
+   if owners := getOwners(prizeEntries) ; !slices.Equal(owners, expectedOwners) {
+      pTest.Errorf("Owners %v, expected %v", owners, expectedOwners)
+   }
+
+   if !slices.Equal(ranks, expectedRanks) {
+      pTest.Errorf("Ranks %v, expected %v", ranks, expectedRanks)
+   }
+
+   if winners := prizeEntries.Winners() ; len(winners) != 1 || winners[0].Owner != "Cobras" {
+      pTest.Errorf("Winners %v, expected [Cobras]", getOwners(winners))
+   }
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func TestRankPrizeEntriesSharedWin(pTest *testing.T) {
+
+   prizeEntries := PrizeEntries{
+      {Score: 5.0, Owner: "Aces"},
+      {Score: 3.0, Owner: "Bandits"},
+      {Score: 3.0, Owner: "Cobras"},
+   }
+
+   decidingTieBreaker := prizeEntries.Rank(SortAscending, nil)
+
+   if decidingTieBreaker != "" {
+      pTest.Errorf("Deciding tie-breaker %q, expected none", decidingTieBreaker)
+   }
+
+   if winners := getOwners(prizeEntries.Winners()) ; !slices.Equal(winners, []string{"Bandits", "Cobras"}) {
+      pTest.Errorf("Winners %v, expected [Bandits Cobras]", winners)
+   }
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func TestIneligibleReasons(pTest *testing.T) {
+
+   deadWeight, _ := GetPrize("Dead Weight")
+   biggestLoser, _ := GetPrize("Biggest Loser")
+
+   prizeSchedule := []ScheduledPrize{{5, deadWeight}, {7, biggestLoser}}
+   _, seasonData := newTestSeason(pTest, prizeSchedule)
+
+   expectedReasons := []struct {
+      week int
+      prize Prize
+      owner string
+      reason string
+   }{
+      {5, deadWeight, "Aces", ReasonLostMatchup},
+      {5, deadWeight, "Icemen", ReasonNoOpponent},
+      {7, biggestLoser, "Aces", ReasonTiedMatchup},
+      {7, biggestLoser, "Cobras", ReasonWonMatchup},
+   }
+
+   for _, expectedReason := range expectedReasons {
+
+      summary := GetWeekSummary(expectedReason.prize, seasonData, expectedReason.week)
+      reason := ""
+
+      for _, prizeEntry := range summary.IneligibleEntries {
+         if prizeEntry.Owner == expectedReason.owner {
+            reason = prizeEntry.Reason
+         }
+      }
+
+      if reason != expectedReason.reason {
+         pTest.Errorf("Week %d %s: Reason %q, expected %q", expectedReason.week, expectedReason.owner, reason, expectedReason.reason)
+      }
+   }
+}
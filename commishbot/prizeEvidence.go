@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type PlayerEvidence struct {
+   Player_id string
+   Name string
+   Value float64
+}
+
+//--------------------------------------------------------------------------------------------------
+// PrizeEvidence records the numbers behind a prize entry's score. Players lists the starters that
+// produced it, with the points, fumbles or touchdowns each contributed. Margin prizes fill in the
+// opponent, and lineup prizes fill in the actual and optimal lineups with each player's points.
+//--------------------------------------------------------------------------------------------------
+type PrizeEvidence struct {
+   Players []PlayerEvidence
+   Opponent string
+   OpponentScore float64
+   ActualLineup []PlayerEvidence
+   OptimalLineup []PlayerEvidence
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func MakePlayerEvidence(pWeekData WeekData, pPlayerId string, pValue float64) PlayerEvidence {
+   return PlayerEvidence{pPlayerId, GetPlayerName(pWeekData.mPlayers, pPlayerId), pValue}
+}
+
+//--------------------------------------------------------------------------------------------------
+// Describe renders the evidence as one line per item, or no lines when there is no evidence.
+//--------------------------------------------------------------------------------------------------
+func (evidence PrizeEvidence) Describe() []string {
+   var lines []string
+
+   for _, player := range evidence.Players {
+      lines = append(lines, fmt.Sprintf("Player: %s (Id: %s), %.2f", player.Name, player.Player_id, player.Value))
+   }
+
+   if evidence.Opponent != "" {
+      lines = append(lines, fmt.Sprintf("Opponent: %s, Score: %.2f", evidence.Opponent, evidence.OpponentScore))
+   }
+
+   if evidence.ActualLineup != nil {
+      lines = append(lines, "Lineup: " + describeLineup(evidence.ActualLineup))
+   }
+
+   if evidence.OptimalLineup != nil {
+      lines = append(lines, "Optimal Lineup: " + describeLineup(evidence.OptimalLineup))
+   }
+
+   return lines
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func describeLineup(pLineup []PlayerEvidence) string {
+   var players []string
+   total := 0.0
+
+   for _, player := range pLineup {
+      players = append(players, fmt.Sprintf("%s %.2f", player.Name, player.Value))
+      total += player.Value
+   }
+
+   return fmt.Sprintf("%s (Total: %.2f)", strings.Join(players, ", "), total)
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func TestPrizeEvidence(pTest *testing.T) {
+
+   prizeSchedule := makeDefaultTestSchedule(pTest)
+   _, seasonData := newTestSeason(pTest, prizeSchedule)
+
+   // Week 3 MVP
+   mvp := GetWeekSummary(prizeSchedule[2].mPrize, seasonData, 3).PrizeEntries[0]
+
+   if players := mvp.Evidence.Players ; len(players) != 1 || players[0].Name == players[0].Player_id || players[0].Value != mvp.Score {
+      pTest.Errorf("Week 3: MVP evidence %+v, expected one named starter scoring %f", players, mvp.Score)
+   }
+
+   // Week 7 Biggest Blowout
+   blowout := GetWeekSummary(prizeSchedule[6].mPrize, seasonData, 7)
+
+   for _, prizeEntry := range blowout.PrizeEntries {
+
+      evidence := prizeEntry.Evidence
+      opponent := findPrizeEntry(blowout.PrizeEntries, evidence.Opponent)
+
+      if opponent == nil {
+         opponent = findPrizeEntry(blowout.IneligibleEntries, evidence.Opponent)
+      }
+
+      if opponent == nil || evidence.OpponentScore == 0.0 {
+         pTest.Errorf("Week 7 %s: Missing opponent evidence %+v", prizeEntry.Owner, evidence)
+      }
+   }
+
+   // Week 8 Best Manager
+   for _, prizeEntry := range GetWeekSummary(prizeSchedule[7].mPrize, seasonData, 8).PrizeEntries {
+
+      actualPoints := 0.0
+      optimalPoints := 0.0
+
+      for _, player := range prizeEntry.Evidence.ActualLineup {
+         actualPoints += player.Value
+      }
+
+      for _, player := range prizeEntry.Evidence.OptimalLineup {
+         optimalPoints += player.Value
+      }
+
+      if math.Abs(actualPoints / optimalPoints * 100.0 - prizeEntry.Score) > 1e-6 {
+         pTest.Errorf("Week 8 %s: Lineups score %f, expected %f", prizeEntry.Owner, actualPoints / optimalPoints * 100.0, prizeEntry.Score)
+      }
+   }
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func getEvidencePlayerIds(pPlayers []PlayerEvidence) []string {
+   var playerIds []string
+
+   for _, player := range pPlayers {
+      playerIds = append(playerIds, player.Player_id)
+   }
+
+   return playerIds
+}
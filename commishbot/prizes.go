@@ -0,0 +1,590 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"strconv"
+)
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func init() {
+   RegisterPrize(HotStartPrize{PrizeInfo{"Hot Start", "Highest Starting Team Score", 0, SortDescending, []TieBreaker{TieBreakerBenchPoints, TieBreakerFewestMoves}}})
+   RegisterPrize(DeadWeightPrize{PrizeInfo{"Dead Weight", "Lowest Starting Player Score, Wins Matchup", PrizeDataPlayers, SortAscending, defaultTieBreakers}})
+   RegisterPrize(MvpPrize{PrizeInfo{"MVP", "Highest Starting Player Score", PrizeDataPlayers, SortDescending, defaultTieBreakers}})
+   RegisterPrize(BenchWarmersPrize{PrizeInfo{"Bench Warmers", "Highest Team Bench Score", 0, SortDescending, []TieBreaker{TieBreakerStarterPoints, TieBreakerFewestMoves}}})
+   RegisterPrize(BiggestLoserPrize{PrizeInfo{"Biggest Loser", "Highest Starting Team Score, Loses Matchup", 0, SortDescending, defaultTieBreakers}})
+   RegisterPrize(PhotoFinishPrize{PrizeInfo{"Photo Finish", "Team With Closest Margin Of Victory", 0, SortAscending, defaultTieBreakers}})
+   RegisterPrize(BiggestBlowoutPrize{PrizeInfo{"Biggest Blowout", "Team With The Largest Margin of Victory", 0, SortDescending, defaultTieBreakers}})
+   RegisterPrize(BestManagerPrize{PrizeInfo{"Best Manager", "Team Closest To A Perfect Lineup Based On Their Roster", PrizeDataPlayers, SortDescending, defaultTieBreakers}})
+   RegisterPrize(WorstManagerPrize{PrizeInfo{"Worst Manager", "Team Farthest From A Perfect Lineup Based On Their Roster", PrizeDataPlayers, SortAscending, defaultTieBreakers}})
+   RegisterPrize(OverachieverPrize{PrizeInfo{"Overachiver", "Team With The Most Points Over Their Weekly Projection", PrizeDataProjections, SortDescending, defaultTieBreakers}})
+   RegisterPrize(UnderperformerPrize{PrizeInfo{"Underperformer", "Team With The Most Points Under Their Weekly Projection", PrizeDataProjections, SortAscending, defaultTieBreakers}})
+   RegisterPrize(ButterfingersPrize{PrizeInfo{"Butterfingers", "Most Starting Team Fumbles", PrizeDataPlayerStats | PrizeDataPlayers, SortDescending, defaultTieBreakers}})
+   RegisterPrize(MakeBlackjackPrize(21.0))
+   RegisterPrize(TouchdownDancePrize{PrizeInfo{"Touchdown Dance", "Team With The Most Touchdowns (Excludes QB Passing Touchdowns)", PrizeDataPlayerStats | PrizeDataPlayers, SortDescending, defaultTieBreakers}})
+   RegisterPrize(EarlyExitPrize{PrizeInfo{"Early Exit", "Highest Starting Team Score, Eliminated From The Playoffs", PrizeDataBrackets, SortDescending, defaultTieBreakers}})
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type HotStartPrize struct {
+   PrizeInfo
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (prize HotStartPrize) Score(pWeekData WeekData, pMatchup Matchup) (PrizeEntry, error) {
+   var prizeEntry PrizeEntry
+   prizeEntry.Score = pMatchup.GetTotalStarterPoints()
+
+   return prizeEntry, nil
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type DeadWeightPrize struct {
+   PrizeInfo
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (prize DeadWeightPrize) Score(pWeekData WeekData, pMatchup Matchup) (PrizeEntry, error) {
+
+   margin, evidence, err := getMatchupMargin(pWeekData, pMatchup)
+
+   if errors.Is(err, ErrNoOpponent) {
+      return MakeIneligibleEntry(ReasonNoOpponent), nil
+   }
+
+   if err != nil {
+      return PrizeEntry{}, err
+   }
+
+   prizeEntry := getLowestStarterEntry(pWeekData, pMatchup)
+
+   if margin <= 0.0 {
+      prizeEntry = MakeIneligibleEntry(getMatchupResultReason(margin))
+   }
+
+   prizeEntry.Evidence.Opponent = evidence.Opponent
+   prizeEntry.Evidence.OpponentScore = evidence.OpponentScore
+
+   return prizeEntry, nil
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type MvpPrize struct {
+   PrizeInfo
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (prize MvpPrize) Score(pWeekData WeekData, pMatchup Matchup) (PrizeEntry, error) {
+
+   if len(pMatchup.Starters_points) == 0 {
+      return MakeIneligibleEntry(ReasonNoStarters), nil
+   }
+
+   mvpIdx := 0
+
+   for idx, starterPoints := range pMatchup.Starters_points {
+      if starterPoints > pMatchup.Starters_points[mvpIdx] {
+         mvpIdx = idx
+      }
+   }
+
+   return makeStarterEntry(pWeekData, pMatchup, mvpIdx), nil
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type BenchWarmersPrize struct {
+   PrizeInfo
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (prize BenchWarmersPrize) Score(pWeekData WeekData, pMatchup Matchup) (PrizeEntry, error) {
+   var prizeEntry PrizeEntry
+   prizeEntry.Score = 0.0
+
+   for _, benchPlayerPoints := range pMatchup.GetBenchPlayerPoints() {
+      prizeEntry.Score += benchPlayerPoints
+   }
+
+   return prizeEntry, nil
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type BiggestLoserPrize struct {
+   PrizeInfo
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (prize BiggestLoserPrize) Score(pWeekData WeekData, pMatchup Matchup) (PrizeEntry, error) {
+
+   margin, evidence, err := getMatchupMargin(pWeekData, pMatchup)
+
+   if errors.Is(err, ErrNoOpponent) {
+      return MakeIneligibleEntry(ReasonNoOpponent), nil
+   }
+
+   if err != nil {
+      return PrizeEntry{}, err
+   }
+
+   var prizeEntry PrizeEntry
+   prizeEntry.Score = pMatchup.GetTotalStarterPoints()
+
+   if margin >= 0.0 {
+      prizeEntry = MakeIneligibleEntry(getMatchupResultReason(margin))
+   }
+
+   prizeEntry.Evidence = evidence
+
+   return prizeEntry, nil
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type PhotoFinishPrize struct {
+   PrizeInfo
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (prize PhotoFinishPrize) Score(pWeekData WeekData, pMatchup Matchup) (PrizeEntry, error) {
+   return getMarginOfVictoryEntry(pWeekData, pMatchup)
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type BiggestBlowoutPrize struct {
+   PrizeInfo
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (prize BiggestBlowoutPrize) Score(pWeekData WeekData, pMatchup Matchup) (PrizeEntry, error) {
+   return getMarginOfVictoryEntry(pWeekData, pMatchup)
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type BestManagerPrize struct {
+   PrizeInfo
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (prize BestManagerPrize) Score(pWeekData WeekData, pMatchup Matchup) (PrizeEntry, error) {
+   return getLineupEfficiencyEntry(pWeekData, pMatchup), nil
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type WorstManagerPrize struct {
+   PrizeInfo
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (prize WorstManagerPrize) Score(pWeekData WeekData, pMatchup Matchup) (PrizeEntry, error) {
+   return getLineupEfficiencyEntry(pWeekData, pMatchup), nil
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type OverachieverPrize struct {
+   PrizeInfo
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (prize OverachieverPrize) Score(pWeekData WeekData, pMatchup Matchup) (PrizeEntry, error) {
+   return getProjectionDeltaEntry(pWeekData, pMatchup)
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type UnderperformerPrize struct {
+   PrizeInfo
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (prize UnderperformerPrize) Score(pWeekData WeekData, pMatchup Matchup) (PrizeEntry, error) {
+   return getProjectionDeltaEntry(pWeekData, pMatchup)
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type ButterfingersPrize struct {
+   PrizeInfo
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (prize ButterfingersPrize) Score(pWeekData WeekData, pMatchup Matchup) (PrizeEntry, error) {
+   return getStarterStatEntry(pWeekData, pMatchup, GetNumFumbles), nil
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type BlackjackPrize struct {
+   PrizeInfo
+   mTarget float64
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func MakeBlackjackPrize(pTarget float64) BlackjackPrize {
+   criteria := "Staring Player Score Closest to " + formatTarget(pTarget) + " Without Going Over"
+   return BlackjackPrize{PrizeInfo{"Blackjack", criteria, PrizeDataPlayers, SortDescending, defaultTieBreakers}, pTarget}
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (prize BlackjackPrize) Configure(pParams map[string]json.RawMessage) (Prize, error) {
+   target := prize.mTarget
+
+   for paramKey, paramValue := range pParams {
+      if paramKey != "Target" {
+         return nil, errors.New("unknown parameter " + paramKey)
+      }
+
+      err := json.Unmarshal([]byte(paramValue), &target)
+
+      if err != nil || target <= 0.0 {
+         return nil, errors.New("Target must be a positive number")
+      }
+   }
+
+   return MakeBlackjackPrize(target), nil
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (prize BlackjackPrize) Params() map[string]any {
+   return map[string]any{"Target": prize.mTarget}
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (prize BlackjackPrize) Score(pWeekData WeekData, pMatchup Matchup) (PrizeEntry, error) {
+
+   closestIdx := -1
+
+   for idx, starterPoints := range pMatchup.Starters_points {
+      if starterPoints <= prize.mTarget && (closestIdx < 0 || pMatchup.Starters_points[closestIdx] < starterPoints) {
+         closestIdx = idx
+      }
+   }
+
+   if closestIdx < 0 {
+      return MakeIneligibleEntry("No Starting Player Scored " + formatTarget(prize.mTarget) + " Or Less"), nil
+   }
+
+   return makeStarterEntry(pWeekData, pMatchup, closestIdx), nil
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type TouchdownDancePrize struct {
+   PrizeInfo
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (prize TouchdownDancePrize) Score(pWeekData WeekData, pMatchup Matchup) (PrizeEntry, error) {
+   return getStarterStatEntry(pWeekData, pMatchup, GetNumNonPassingTds), nil
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type EarlyExitPrize struct {
+   PrizeInfo
+}
+
+//--------------------------------------------------------------------------------------------------
+// Only teams knocked out of the winners bracket in the week's round qualify, so the prize has to be
+// scheduled in a playoff week.
+//--------------------------------------------------------------------------------------------------
+func (prize EarlyExitPrize) Score(pWeekData WeekData, pMatchup Matchup) (PrizeEntry, error) {
+
+   round := pWeekData.mLeagueInfo.mLeague.GetPlayoffRound(pWeekData.mWeek)
+
+   if round == 0 {
+      return MakeIneligibleEntry(ReasonNotPlayoffWeek), nil
+   }
+
+   if !slices.Contains(pWeekData.mWinnersBracket.GetEliminatedRosterIds(round), pMatchup.Roster_id) {
+      return MakeIneligibleEntry(ReasonNotEliminated), nil
+   }
+
+   var prizeEntry PrizeEntry
+   prizeEntry.Score = pMatchup.GetTotalStarterPoints()
+
+   if _, evidence, err := getMatchupMargin(pWeekData, pMatchup) ; err == nil {
+      prizeEntry.Evidence = evidence
+   }
+
+   return prizeEntry, nil
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func formatTarget(pTarget float64) string {
+   return strconv.FormatFloat(pTarget, 'f', -1, 64)
+}
+
+//--------------------------------------------------------------------------------------------------
+// getMatchupMargin returns the team's starter points minus their opponent's, along with evidence
+// naming the opponent, or ErrNoOpponent for a team that is on a bye or whose opponent is missing.
+//--------------------------------------------------------------------------------------------------
+func getMatchupMargin(pWeekData WeekData, pMatchup Matchup) (float64, PrizeEvidence, error) {
+
+   matchupOpponentRoster, err := GetMatchupOpponentRoster(pWeekData.mMatchups, pMatchup.Roster_id)
+
+   if err != nil {
+      return 0.0, PrizeEvidence{}, err
+   }
+
+   var evidence PrizeEvidence
+   evidence.Opponent = pWeekData.mLeagueInfo.GetOwnerName(matchupOpponentRoster.Roster_id)
+   evidence.OpponentScore = matchupOpponentRoster.GetTotalStarterPoints()
+
+   return pMatchup.GetTotalStarterPoints() - evidence.OpponentScore, evidence, nil
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func getMatchupResultReason(pMargin float64) string {
+
+   if pMargin > 0.0 {
+      return ReasonWonMatchup
+   }
+
+   if pMargin < 0.0 {
+      return ReasonLostMatchup
+   }
+
+   return ReasonTiedMatchup
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func getMarginOfVictoryEntry(pWeekData WeekData, pMatchup Matchup) (PrizeEntry, error) {
+
+   margin, evidence, err := getMatchupMargin(pWeekData, pMatchup)
+
+   if errors.Is(err, ErrNoOpponent) {
+      return MakeIneligibleEntry(ReasonNoOpponent), nil
+   }
+
+   if err != nil {
+      return PrizeEntry{}, err
+   }
+
+   var prizeEntry PrizeEntry
+   prizeEntry.Score = margin
+
+   if margin <= 0.0 {
+      prizeEntry = MakeIneligibleEntry(getMatchupResultReason(margin))
+   }
+
+   prizeEntry.Evidence = evidence
+
+   return prizeEntry, nil
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func getLowestStarterEntry(pWeekData WeekData, pMatchup Matchup) PrizeEntry {
+
+   if len(pMatchup.Starters_points) == 0 {
+      return MakeIneligibleEntry(ReasonNoStarters)
+   }
+
+   lowestIdx := 0
+
+   for idx, starterPoints := range pMatchup.Starters_points {
+      if starterPoints < pMatchup.Starters_points[lowestIdx] {
+         lowestIdx = idx
+      }
+   }
+
+   return makeStarterEntry(pWeekData, pMatchup, lowestIdx)
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func makeStarterEntry(pWeekData WeekData, pMatchup Matchup, pStarterIdx int) PrizeEntry {
+   var prizeEntry PrizeEntry
+   prizeEntry.Score = pMatchup.Starters_points[pStarterIdx]
+
+   if pStarterIdx < len(pMatchup.Starters) {
+      prizeEntry.Evidence.Players = []PlayerEvidence{MakePlayerEvidence(pWeekData, pMatchup.Starters[pStarterIdx], prizeEntry.Score)}
+   }
+
+   return prizeEntry
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func getStarterStatEntry(pWeekData WeekData, pMatchup Matchup, pGetStat func(map[string]PlayerStats, string) float64) PrizeEntry {
+   var prizeEntry PrizeEntry
+   prizeEntry.Score = 0.0
+
+   for _, starter := range pMatchup.Starters {
+      starterStat := pGetStat(pWeekData.mPlayerStats, starter)
+
+      if starterStat != 0.0 {
+         prizeEntry.Score += starterStat
+         prizeEntry.Evidence.Players = append(prizeEntry.Evidence.Players, MakePlayerEvidence(pWeekData, starter, starterStat))
+      }
+   }
+
+   return prizeEntry
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func getLineupEfficiencyEntry(pWeekData WeekData, pMatchup Matchup) PrizeEntry {
+   var prizeEntry PrizeEntry
+
+   for idx, starter := range pMatchup.Starters {
+      if starter != EmptyPlayerId && idx < len(pMatchup.Starters_points) {
+         prizeEntry.Evidence.ActualLineup = append(prizeEntry.Evidence.ActualLineup, MakePlayerEvidence(pWeekData, starter, pMatchup.Starters_points[idx]))
+      }
+   }
+
+   lineupConstraints := getLineupConstraints(pWeekData, pMatchup)
+   optimalLineup := pMatchup.GetOptimalLineup(pWeekData.mPlayers, pWeekData.mLeagueInfo.mLeague.mRosterPositionCounts, lineupConstraints)
+
+   for _, playerId := range optimalLineup.GetPlayerIds() {
+      prizeEntry.Evidence.OptimalLineup = append(prizeEntry.Evidence.OptimalLineup, MakePlayerEvidence(pWeekData, playerId, pMatchup.Players_points[playerId]))
+   }
+
+   if optimalLineup.Points <= 0.0 {
+      return MakeIneligibleEntry("No Optimal Lineup Points")
+   }
+
+   prizeEntry.Score = pMatchup.GetTotalStarterPoints() / optimalLineup.Points * 100.0
+
+   return prizeEntry
+}
+
+//--------------------------------------------------------------------------------------------------
+// getLineupConstraints keeps bench players on the roster's reserve and taxi squads out of the optimal
+// lineup. Sleeper only reports the current reserve and taxi squads, so they are ignored for earlier
+// weeks. With game times for the week, starters whose game had kicked off by the lineup lock stay in
+// their slot and bench players whose game had kicked off cannot be moved in.
+//--------------------------------------------------------------------------------------------------
+func getLineupConstraints(pWeekData WeekData, pMatchup Matchup) LineupConstraints {
+
+   var lineupConstraints LineupConstraints
+   lineupConstraints.ExcludedPlayerIds = make(map[string]bool)
+
+   if roster, hasRoster := pWeekData.mLeagueInfo.GetRoster(pMatchup.Roster_id) ; hasRoster && pWeekData.mIsRosterWeek {
+      for _, playerId := range slices.Concat(roster.Reserve, roster.Taxi) {
+         if !slices.Contains(pMatchup.Starters, playerId) {
+            lineupConstraints.ExcludedPlayerIds[playerId] = true
+         }
+      }
+   }
+
+   startingSlots := pWeekData.mLeagueInfo.mLeague.GetStartingSlots()
+
+   for idx, starter := range pMatchup.Starters {
+      if starter != EmptyPlayerId && idx < len(startingSlots) && pWeekData.mGameTimes.IsLocked(pWeekData.mPlayers[starter].Team) {
+         lineupConstraints.LockedSlots = append(lineupConstraints.LockedSlots, LineupSlot{startingSlots[idx], starter})
+      }
+   }
+
+   for _, benchPlayer := range pMatchup.GetBenchPlayers() {
+      if pWeekData.mGameTimes.IsLocked(pWeekData.mPlayers[benchPlayer].Team) {
+         lineupConstraints.ExcludedPlayerIds[benchPlayer] = true
+      }
+   }
+
+   return lineupConstraints
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func getProjectionDeltaEntry(pWeekData WeekData, pMatchup Matchup) (PrizeEntry, error) {
+   starterPlayerPoints := pMatchup.GetStarterPlayerPoints()
+
+   var prizeEntry PrizeEntry
+   prizeEntry.Score = 0.0
+
+   for _, starter := range pMatchup.Starters {
+
+      if starter == EmptyPlayerId {
+         continue
+      }
+
+      starterProjection, err := pWeekData.mProjections.GetWeekScore(starter, pWeekData.mWeek, pWeekData.mLeagueInfo.mLeague.Scoring_settings)
+
+      if err != nil {
+         return PrizeEntry{}, err
+      }
+
+      starterPoints, hasStarterPoints := starterPlayerPoints[starter]
+
+      if !hasStarterPoints {
+         return PrizeEntry{}, errors.New("Failed to retrieve player " + starter + " points")
+      }
+
+      prizeEntry.Score += (starterPoints - starterProjection)
+   }
+
+   return prizeEntry, nil
+}
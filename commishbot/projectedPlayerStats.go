@@ -1,65 +1,72 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
+	"fmt"
 	"strconv"
 )
 
 //--------------------------------------------------------------------------------------------------
 //
 //--------------------------------------------------------------------------------------------------
-func GetProjectedPlayerStatsData(pPlayerId string, pYear int) string {
-   return GetHttpResponse("https://api.sleeper.com/projections/nfl/player/" + pPlayerId + "?season_type=regular&season=" + strconv.Itoa(pYear) + "&grouping=week")
+func (client *SleeperClient) GetProjectedPlayerStatsData(pContext context.Context, pPlayerId string, pYear int) (string, error) {
+   return client.getHttpResponse(pContext, client.mProjectionsBaseUrl + "/projections/nfl/player/" + pPlayerId + "?season_type=regular&season=" + strconv.Itoa(pYear) + "&grouping=week", projectionsCacheTtl)
 }
 
 //--------------------------------------------------------------------------------------------------
 //
 //--------------------------------------------------------------------------------------------------
-func GetProjectedPlayerStats(pPlayerId string, pYear int) map[string]json.RawMessage {
+func (client *SleeperClient) GetProjectedPlayerStats(pContext context.Context, pPlayerId string, pYear int) (map[string]json.RawMessage, error) {
 
-   projectedPlayerStatsData := GetProjectedPlayerStatsData(pPlayerId, pYear)
+   projectedPlayerStatsData, err := client.GetProjectedPlayerStatsData(pContext, pPlayerId, pYear)
+
+   if err != nil {
+      return nil, err
+   }
 
    var projectedPlayerStats map[string]json.RawMessage
-   err := json.Unmarshal([]byte(projectedPlayerStatsData), &projectedPlayerStats)
-   check(err)
+   err = unmarshalSleeperData(projectedPlayerStatsData, strconv.Itoa(pYear) + " projections for player Id " + pPlayerId, &projectedPlayerStats)
+
+   if err != nil {
+      return nil, err
+   }
 
-   return projectedPlayerStats
+   return projectedPlayerStats, nil
 }
 
 //--------------------------------------------------------------------------------------------------
 //
 //--------------------------------------------------------------------------------------------------
-func GetProjectedPlayerWeekStats(pPlayerId string, pYear int, pWeek int) (map[string]float64, error) {
+func GetProjectedWeekStats(pProjectedPlayerStats map[string]json.RawMessage, pPlayerId string, pYear int, pWeek int) (map[string]float64, error) {
 
    yearStr := strconv.Itoa(pYear)
    weekStr := strconv.Itoa(pWeek)
 
-   projectedPlayerStats := GetProjectedPlayerStats(pPlayerId, pYear)
-   projectedWeekData, hasKey := projectedPlayerStats[weekStr]
+   projectedWeekData, hasKey := pProjectedPlayerStats[weekStr]
 
    if !hasKey {
-      return nil, errors.New("Failed to retrieve " + yearStr + " week " + weekStr + " projections for player Id " + pPlayerId)
+      return nil, fmt.Errorf("Failed to retrieve %s week %s projections for player Id %s: %w", yearStr, weekStr, pPlayerId, ErrNotFound)
    }
 
    var projectedWeek map[string]json.RawMessage
    err := json.Unmarshal([]byte(projectedWeekData), &projectedWeek)
 
    if err != nil {
-      return nil, errors.New("Failed to unmarshal " + yearStr + " week " + weekStr + " projections for player Id " + pPlayerId)
+      return nil, fmt.Errorf("Failed to unmarshal %s week %s projections for player Id %s: %w", yearStr, weekStr, pPlayerId, ErrDecode)
    }
 
    projectedWeekStatsData, hasKey := projectedWeek["stats"]
 
    if !hasKey {
-      return nil, errors.New("Failed to retrieve " + yearStr + " week " + weekStr + " stat projections for player Id " + pPlayerId)
+      return nil, fmt.Errorf("Failed to retrieve %s week %s stat projections for player Id %s: %w", yearStr, weekStr, pPlayerId, ErrNotFound)
    }
 
    var projectedWeekStats map[string]float64
    err = json.Unmarshal([]byte(projectedWeekStatsData), &projectedWeekStats)
 
    if err != nil {
-      return nil, errors.New("Failed to unmarshal " + yearStr + " week " + weekStr + " stat projections for player Id " + pPlayerId)
+      return nil, fmt.Errorf("Failed to unmarshal %s week %s stat projections for player Id %s: %w", yearStr, weekStr, pPlayerId, ErrDecode)
    }
 
    return projectedWeekStats, nil
@@ -68,17 +75,11 @@ func GetProjectedPlayerWeekStats(pPlayerId string, pYear int, pWeek int) (map[st
 //--------------------------------------------------------------------------------------------------
 //
 //--------------------------------------------------------------------------------------------------
-func GetProjectedPlayerWeekScore(pPlayerId string, pYear int, pWeek int, pScoringSettings map[string]json.RawMessage) (float64, error) {
-
-   projectedWeekStats, err := GetProjectedPlayerWeekStats(pPlayerId, pYear, pWeek)
-
-   if err != nil {
-      return 0.0, err
-   }
+func GetProjectedScore(pProjectedWeekStats map[string]float64, pScoringSettings map[string]json.RawMessage) (float64, error) {
 
    starterProjection := 0.0
 
-   for statKey, statValue := range projectedWeekStats {
+   for statKey, statValue := range pProjectedWeekStats {
 
       scoringValue, err := GetScoringValue(pScoringSettings, statKey)
 
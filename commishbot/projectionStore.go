@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+)
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type ProjectionStore struct {
+   mClient *SleeperClient
+   mYear int
+   mParallelism int
+
+   mMutex sync.Mutex
+   mEntries map[string]*projectionEntry
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type projectionEntry struct {
+   mDone chan struct{}
+   mProjections map[string]json.RawMessage
+   mErr error
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func NewProjectionStore(pClient *SleeperClient, pYear int, pParallelism int) *ProjectionStore {
+   var store ProjectionStore
+
+   store.mClient = pClient
+   store.mYear = pYear
+   store.mParallelism = max(1, pParallelism)
+   store.mEntries = make(map[string]*projectionEntry)
+
+   return &store
+}
+
+//--------------------------------------------------------------------------------------------------
+// Load fetches the season projections of every player that has not been fetched yet, at most
+// mParallelism at a time. A player Sleeper has no projections for is projected 0 points, and a
+// player whose request failed is requested again by the next Load. The first failure is returned
+// once every request has finished.
+//--------------------------------------------------------------------------------------------------
+func (store *ProjectionStore) Load(pContext context.Context, pPlayerIds []string) error {
+
+   semaphore := make(chan struct{}, store.mParallelism)
+   var waitGroup sync.WaitGroup
+
+   var errMutex sync.Mutex
+   var firstErr error
+
+   for _, playerId := range pPlayerIds {
+
+      entry, isOwner := store.getEntry(playerId)
+
+      if !isOwner {
+         continue
+      }
+
+      waitGroup.Add(1)
+
+      go func(pPlayerId string, pEntry *projectionEntry) {
+         defer waitGroup.Done()
+         defer close(pEntry.mDone)
+
+         select {
+         case semaphore <- struct{}{}:
+            defer func() { <-semaphore }()
+            pEntry.mProjections, pEntry.mErr = store.mClient.GetProjectedPlayerStats(pContext, pPlayerId, store.mYear)
+
+         case <-pContext.Done():
+            pEntry.mErr = pContext.Err()
+         }
+
+         // GetWeekStats reports the player's missing weeks
+         if errors.Is(pEntry.mErr, ErrNotFound) {
+            pEntry.mProjections = nil
+            pEntry.mErr = nil
+         }
+
+         if pEntry.mErr != nil {
+            store.mMutex.Lock()
+            delete(store.mEntries, pPlayerId)
+            store.mMutex.Unlock()
+
+            errMutex.Lock()
+
+            if firstErr == nil {
+               firstErr = pEntry.mErr
+            }
+
+            errMutex.Unlock()
+         }
+      }(playerId, entry)
+   }
+
+   waitGroup.Wait()
+
+   if firstErr != nil {
+      return firstErr
+   }
+
+   for _, playerId := range pPlayerIds {
+      _, err := store.waitEntry(pContext, playerId)
+
+      if err != nil {
+         return err
+      }
+   }
+
+   return nil
+}
+
+//--------------------------------------------------------------------------------------------------
+// GetWeekStats returns the player's projected stats for pWeek, which are empty when Sleeper has no
+// projection for that week.
+//--------------------------------------------------------------------------------------------------
+func (store *ProjectionStore) GetWeekStats(pPlayerId string, pWeek int) (map[string]float64, error) {
+
+   store.mMutex.Lock()
+   entry, hasEntry := store.mEntries[pPlayerId]
+   store.mMutex.Unlock()
+
+   if !hasEntry {
+      return nil, fmt.Errorf("Projections for player Id %s have not been loaded: %w", pPlayerId, ErrNotFound)
+   }
+
+   <-entry.mDone
+
+   if entry.mErr != nil {
+      return nil, entry.mErr
+   }
+
+   projectedWeekStats, err := GetProjectedWeekStats(entry.mProjections, pPlayerId, store.mYear, pWeek)
+
+   if errors.Is(err, ErrNotFound) {
+      log.Printf("Player Id %s has no %d week %d projection, projecting 0 points", pPlayerId, store.mYear, pWeek)
+      return map[string]float64{}, nil
+   }
+
+   return projectedWeekStats, err
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (store *ProjectionStore) GetWeekScore(pPlayerId string, pWeek int, pScoringSettings map[string]json.RawMessage) (float64, error) {
+
+   projectedWeekStats, err := store.GetWeekStats(pPlayerId, pWeek)
+
+   if err != nil {
+      return 0.0, err
+   }
+
+   return GetProjectedScore(projectedWeekStats, pScoringSettings)
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (store *ProjectionStore) getEntry(pPlayerId string) (*projectionEntry, bool) {
+
+   store.mMutex.Lock()
+   defer store.mMutex.Unlock()
+
+   if entry, hasEntry := store.mEntries[pPlayerId] ; hasEntry {
+      return entry, false
+   }
+
+   entry := &projectionEntry{mDone: make(chan struct{})}
+   store.mEntries[pPlayerId] = entry
+
+   return entry, true
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (store *ProjectionStore) waitEntry(pContext context.Context, pPlayerId string) (*projectionEntry, error) {
+
+   store.mMutex.Lock()
+   entry, hasEntry := store.mEntries[pPlayerId]
+   store.mMutex.Unlock()
+
+   if !hasEntry {
+      return nil, fmt.Errorf("Projections for player Id %s failed to load: %w", pPlayerId, ErrNotFound)
+   }
+
+   select {
+   case <-entry.mDone:
+      return entry, entry.mErr
+   case <-pContext.Done():
+      return nil, pContext.Err()
+   }
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"testing"
+)
+
+//--------------------------------------------------------------------------------------------------
+// Players and weeks without a projection are projected 0 points instead of failing the week.
+//--------------------------------------------------------------------------------------------------
+func TestMissingProjectionsProjectZero(pTest *testing.T) {
+
+   _, client := newTestClient(pTest)
+   store := NewProjectionStore(client, testYear, 2)
+
+   // The fixtures have no projections for player 9999 and only weeks 10 and 11 for player 1100
+   err := store.Load(context.Background(), []string{"1100", "9999"})
+
+   if err != nil {
+      pTest.Fatalf("Load: %s", err.Error())
+   }
+
+   for playerId, week := range map[string]int{"9999": 10, "1100": 12} {
+
+      projection, err := store.GetWeekScore(playerId, week, nil)
+
+      if err != nil || projection != 0.0 {
+         pTest.Errorf("Player %s week %d projected %f (%v), expected 0", playerId, week, projection, err)
+      }
+   }
+}
+
+//--------------------------------------------------------------------------------------------------
+// A failed request is not kept, so a later Load fetches the player again.
+//--------------------------------------------------------------------------------------------------
+func TestFailedProjectionsAreRequestedAgain(pTest *testing.T) {
+
+   server, client := newTestClient(pTest)
+   store := NewProjectionStore(client, testYear, 1)
+
+   cancelledContext, cancel := context.WithCancel(context.Background())
+   cancel()
+
+   if err := store.Load(cancelledContext, []string{"1100"}) ; err == nil {
+      pTest.Fatalf("Load with a cancelled context did not fail")
+   }
+
+   err := store.Load(context.Background(), []string{"1100"})
+
+   if err != nil {
+      pTest.Fatalf("Load: %s", err.Error())
+   }
+
+   if _, err := store.GetWeekStats("1100", 10) ; err != nil {
+      pTest.Errorf("Player 1100 week 10 projection: %s", err.Error())
+   }
+
+   if requestCount := server.RequestCount("/projections/nfl/player/1100") ; requestCount != 1 {
+      pTest.Errorf("Projections for player 1100 requested %d times, expected 1", requestCount)
+   }
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func TestProjectionsFetchedOncePerPlayer(pTest *testing.T) {
+
+   server, _ := newTestSeason(pTest, makeDefaultTestSchedule(pTest))
+
+   // Player 1100 starts for the Aces in both projection weeks
+   if requestCount := server.RequestCount("/projections/nfl/player/1100") ; requestCount != 1 {
+      pTest.Errorf("Projections for player 1100 requested %d times, expected 1", requestCount)
+   }
+
+   if requestCount := server.RequestCount("/projections/nfl/player/0") ; requestCount != 0 {
+      pTest.Errorf("Projections for an empty starting slot requested %d times, expected 0", requestCount)
+   }
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type RateLimiter struct {
+   mMutex sync.Mutex
+   mTokensPerSecond float64
+   mBurst float64
+   mTokens float64
+   mLastRefill time.Time
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func NewRateLimiter(pRequestsPerMinute int, pBurst int) *RateLimiter {
+   var limiter RateLimiter
+
+   limiter.mTokensPerSecond = float64(pRequestsPerMinute) / 60.0
+   limiter.mBurst = math.Max(1.0, float64(pBurst))
+   limiter.mTokens = limiter.mBurst
+   limiter.mLastRefill = time.Now()
+
+   return &limiter
+}
+
+//--------------------------------------------------------------------------------------------------
+// Wait takes a token, blocking until one is available. Tokens are reserved up front, so concurrent
+// callers queue behind each other rather than all waking at once when the bucket refills.
+//--------------------------------------------------------------------------------------------------
+func (limiter *RateLimiter) Wait(pContext context.Context) error {
+
+   limiter.mMutex.Lock()
+
+   now := time.Now()
+   elapsed := now.Sub(limiter.mLastRefill).Seconds()
+   limiter.mTokens = math.Min(limiter.mBurst, limiter.mTokens + elapsed * limiter.mTokensPerSecond)
+   limiter.mLastRefill = now
+   limiter.mTokens -= 1.0
+
+   if limiter.mTokens >= 0.0 {
+      limiter.mMutex.Unlock()
+      return nil
+   }
+
+   delay := time.Duration(-limiter.mTokens / limiter.mTokensPerSecond * float64(time.Second))
+   limiter.mMutex.Unlock()
+
+   timer := time.NewTimer(delay)
+   defer timer.Stop()
+
+   select {
+   case <-timer.C:
+      return nil
+
+   case <-pContext.Done():
+      limiter.mMutex.Lock()
+      limiter.mTokens += 1.0
+      limiter.mMutex.Unlock()
+
+      return pContext.Err()
+   }
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"slices"
+	"sort"
+)
+
+const defaultRecheckTolerance = 0.01
+
+//--------------------------------------------------------------------------------------------------
+// Ranks are zero for entries that are ineligible.
+//--------------------------------------------------------------------------------------------------
+type EntryChange struct {
+   Owner string
+   OldRank int
+   NewRank int
+   OldScore float64
+   NewScore float64
+   OldReason string
+   NewReason string
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type PlayerPointsChange struct {
+   Player_id string
+   Name string
+   OldPoints float64
+   NewPoints float64
+}
+
+//--------------------------------------------------------------------------------------------------
+// A corrected stat line can leave the player's fantasy points unchanged and still move a prize that
+// scores the stats, such as Butterfingers.
+//--------------------------------------------------------------------------------------------------
+type PlayerStatsChange struct {
+   Player_id string
+   Name string
+   OldStats PlayerStats
+   NewStats PlayerStats
+}
+
+//--------------------------------------------------------------------------------------------------
+// RecheckResult compares a finalized week with the same week recomputed from fresh Sleeper data.
+// Error repeats Err for JSON output.
+//--------------------------------------------------------------------------------------------------
+type RecheckResult struct {
+   Week int
+   Prize string
+   InputChanged bool
+   OldWinners []string
+   NewWinners []string
+   EntryChanges []EntryChange
+   PlayerChanges []PlayerPointsChange
+   StatChanges []PlayerStatsChange
+   Error string `json:",omitempty"`
+   Err error `json:"-"`
+}
+
+//--------------------------------------------------------------------------------------------------
+// RecheckWeek recomputes the week of a finalized result and reports every team whose rank,
+// eligibility or score moved by more than pTolerance, along with the players whose points or stat
+// lines changed. The stored result is left as it is.
+//--------------------------------------------------------------------------------------------------
+func RecheckWeek(pSeasonData SeasonData, pPrize Prize, pResult WeekResult, pTolerance float64) RecheckResult {
+
+   var recheckResult RecheckResult
+   recheckResult.Week = pResult.Week
+   recheckResult.Prize = pResult.Prize
+   recheckResult.OldWinners = getOwners(pResult.Summary.PrizeEntries.Winners())
+
+   summary := GetWeekSummary(pPrize, pSeasonData, pResult.Week)
+
+   if summary.Err != nil {
+      return recheckResult.withErr(summary.Err)
+   }
+
+   inputHash, err := pSeasonData.GetWeekInputHash(pResult.Week, pPrize.RequiredData())
+
+   if err != nil {
+      return recheckResult.withErr(err)
+   }
+
+   recheckResult.InputChanged = inputHash != pResult.InputHash
+   recheckResult.NewWinners = getOwners(summary.PrizeEntries.Winners())
+   recheckResult.EntryChanges = getEntryChanges(pResult.Summary, summary, pTolerance)
+
+   var players map[string]Player
+
+   if weekData, err := pSeasonData.GetWeekData(pResult.Week, PrizeDataPlayers) ; err == nil {
+      players = weekData.mPlayers
+   }
+
+   newPlayerPoints := pSeasonData.GetWeekPlayerPoints(pResult.Week)
+
+   for playerId, oldPoints := range pResult.PlayerPoints {
+      if newPoints := newPlayerPoints[playerId] ; math.Abs(newPoints - oldPoints) > pTolerance {
+         recheckResult.PlayerChanges = append(recheckResult.PlayerChanges, PlayerPointsChange{playerId, GetPlayerName(players, playerId), oldPoints, newPoints})
+      }
+   }
+
+   for playerId, newPoints := range newPlayerPoints {
+      if _, hasOldPoints := pResult.PlayerPoints[playerId] ; !hasOldPoints && math.Abs(newPoints) > pTolerance {
+         recheckResult.PlayerChanges = append(recheckResult.PlayerChanges, PlayerPointsChange{playerId, GetPlayerName(players, playerId), 0.0, newPoints})
+      }
+   }
+
+   sort.Slice(recheckResult.PlayerChanges, func(i, j int) bool {
+      return recheckResult.PlayerChanges[i].Player_id < recheckResult.PlayerChanges[j].Player_id
+   })
+
+   if pResult.PlayerStats != nil {
+      newPlayerStats := pSeasonData.GetWeekPlayerStats(pResult.Week)
+
+      for playerId, newStats := range newPlayerStats {
+         if oldStats := pResult.PlayerStats[playerId] ; oldStats != newStats {
+            recheckResult.StatChanges = append(recheckResult.StatChanges, PlayerStatsChange{playerId, GetPlayerName(players, playerId), oldStats, newStats})
+         }
+      }
+
+      for playerId, oldStats := range pResult.PlayerStats {
+         if _, hasNewStats := newPlayerStats[playerId] ; !hasNewStats {
+            recheckResult.StatChanges = append(recheckResult.StatChanges, PlayerStatsChange{playerId, GetPlayerName(players, playerId), oldStats, PlayerStats{}})
+         }
+      }
+
+      sort.Slice(recheckResult.StatChanges, func(i, j int) bool {
+         return recheckResult.StatChanges[i].Player_id < recheckResult.StatChanges[j].Player_id
+      })
+   }
+
+   return recheckResult
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (recheckResult RecheckResult) withErr(pErr error) RecheckResult {
+   recheckResult.Err = pErr
+   recheckResult.Error = pErr.Error()
+
+   return recheckResult
+}
+
+//--------------------------------------------------------------------------------------------------
+// Owners missing from either summary are compared against an ineligible entry that says so.
+//--------------------------------------------------------------------------------------------------
+func getEntryChanges(pOldSummary WeekSummary, pNewSummary WeekSummary, pTolerance float64) []EntryChange {
+
+   oldEntries := make(map[string]PrizeEntry)
+   newOwners := make(map[string]bool)
+
+   for _, prizeEntry := range slices.Concat(pOldSummary.PrizeEntries, pOldSummary.IneligibleEntries) {
+      oldEntries[prizeEntry.Owner] = prizeEntry
+   }
+
+   var entryChanges []EntryChange
+
+   for _, newEntry := range slices.Concat(pNewSummary.PrizeEntries, pNewSummary.IneligibleEntries) {
+
+      newOwners[newEntry.Owner] = true
+
+      oldEntry, hasOldEntry := oldEntries[newEntry.Owner]
+
+      if !hasOldEntry {
+         oldEntry = MakeIneligibleEntry("Not In The Stored Result")
+      }
+
+      scoreChanged := newEntry.Eligibility == Eligible && math.Abs(newEntry.Score - oldEntry.Score) > pTolerance
+
+      if newEntry.Rank == oldEntry.Rank && newEntry.Eligibility == oldEntry.Eligibility && !scoreChanged {
+         continue
+      }
+
+      entryChanges = append(entryChanges, EntryChange{newEntry.Owner, oldEntry.Rank, newEntry.Rank, oldEntry.Score, newEntry.Score, oldEntry.Reason, newEntry.Reason})
+   }
+
+   for _, oldEntry := range slices.Concat(pOldSummary.PrizeEntries, pOldSummary.IneligibleEntries) {
+      if !newOwners[oldEntry.Owner] {
+         newEntry := MakeIneligibleEntry("Not In The Recomputed Result")
+         entryChanges = append(entryChanges, EntryChange{oldEntry.Owner, oldEntry.Rank, newEntry.Rank, oldEntry.Score, newEntry.Score, oldEntry.Reason, newEntry.Reason})
+      }
+   }
+
+   return entryChanges
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (recheckResult RecheckResult) WinnerChanged() bool {
+
+   if len(recheckResult.OldWinners) != len(recheckResult.NewWinners) {
+      return true
+   }
+
+   for idx := range recheckResult.OldWinners {
+      if recheckResult.OldWinners[idx] != recheckResult.NewWinners[idx] {
+         return true
+      }
+   }
+
+   return false
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (recheckResult RecheckResult) HasChanges() bool {
+   return len(recheckResult.EntryChanges) > 0 || len(recheckResult.PlayerChanges) > 0 || len(recheckResult.StatChanges) > 0 || recheckResult.WinnerChanged()
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (recheckResult RecheckResult) Print() {
+
+   if recheckResult.Err != nil {
+      log.Printf("Week %d %s: Recheck failed: %s", recheckResult.Week, recheckResult.Prize, recheckResult.Err.Error())
+      return
+   }
+
+   if !recheckResult.HasChanges() {
+      log.Printf("Week %d %s: No changes", recheckResult.Week, recheckResult.Prize)
+      return
+   }
+
+   log.Printf("Week %d %s: Results changed since they were finalized", recheckResult.Week, recheckResult.Prize)
+
+   if recheckResult.WinnerChanged() {
+      log.Printf("   Winner changed from %v to %v", recheckResult.OldWinners, recheckResult.NewWinners)
+   }
+
+   for _, entryChange := range recheckResult.EntryChanges {
+      log.Printf("   Owner: %s, %s -> %s", entryChange.Owner, describeRecheckEntry(entryChange.OldRank, entryChange.OldScore, entryChange.OldReason), describeRecheckEntry(entryChange.NewRank, entryChange.NewScore, entryChange.NewReason))
+   }
+
+   for _, playerChange := range recheckResult.PlayerChanges {
+      log.Printf("   Player: %s, Points: %.2f -> %.2f", playerChange.Name, playerChange.OldPoints, playerChange.NewPoints)
+   }
+
+   for _, statChange := range recheckResult.StatChanges {
+      log.Printf("   Player: %s, Stats: %+v -> %+v", statChange.Name, statChange.OldStats, statChange.NewStats)
+   }
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func describeRecheckEntry(pRank int, pScore float64, pReason string) string {
+
+   if pReason != "" {
+      return "Ineligible (" + pReason + ")"
+   }
+
+   return fmt.Sprintf("Rank %d, Score: %.2f", pRank, pScore)
+}
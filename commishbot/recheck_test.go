@@ -0,0 +1,165 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+//--------------------------------------------------------------------------------------------------
+// Week 12 Butterfingers is finalized from a stats snapshot taken before the fumbles were recorded.
+// The recheck command fetches the stats again instead of reading the snapshot, and reports the stat
+// corrections even though they leave every player's fantasy points as they were.
+//--------------------------------------------------------------------------------------------------
+func TestRecheckCommandBypassesStatsSnapshot(pTest *testing.T) {
+
+   server, _ := newTestClient(pTest)
+   snapshotDir := pTest.TempDir()
+
+   err := os.WriteFile(filepath.Join(snapshotDir, "Nfl.2023.Stats.Week12.json"), []byte("{}"), 0644)
+
+   if err != nil {
+      pTest.Fatal(err)
+   }
+
+   config := writeTestConfig(pTest, map[string]any{
+      "SleeperBaseUrl": server.BaseUrl(),
+      "SleeperProjectionsBaseUrl": server.ProjectionsBaseUrl(),
+      "StatsSnapshotDir": snapshotDir,
+      "CacheDir": pTest.TempDir(),
+      "PrizeSchedule": []map[string]any{{"Week": 12, "Prize": "Butterfingers"}},
+   })
+
+   runCommand := func(pName string, pArgs ...string) []byte {
+      command, _ := GetCommand(pName)
+
+      client := NewCommandSleeperClient(config, command)
+      client.SetRateLimiter(nil)
+
+      var output bytes.Buffer
+      env := &CommandEnv{mContext: context.Background(), mConfig: config, mClient: client, mFormat: FormatJson, mOutput: &output}
+
+      err := command.Run(env, pArgs)
+
+      if err != nil {
+         pTest.Fatalf("%s: %s", pName, err.Error())
+      }
+
+      return output.Bytes()
+   }
+
+   runCommand("summary", "--week", "12")
+
+   var recheckResults []RecheckResult
+   err = json.Unmarshal(runCommand("recheck"), &recheckResults)
+
+   if err != nil || len(recheckResults) != 1 {
+      pTest.Fatalf("Recheck results %+v (%v), expected week 12", recheckResults, err)
+   }
+
+   recheckResult := recheckResults[0]
+
+   if recheckResult.Error != "" || !recheckResult.InputChanged || !recheckResult.WinnerChanged() || len(recheckResult.StatChanges) == 0 {
+      pTest.Errorf("Recheck gave %+v, expected the corrected stats to change the winner", recheckResult)
+   }
+
+   if len(recheckResult.PlayerChanges) != 0 {
+      pTest.Errorf("Player point changes %+v, expected none", recheckResult.PlayerChanges)
+   }
+
+   if !slices.ContainsFunc(recheckResult.StatChanges, func(pStatChange PlayerStatsChange) bool { return pStatChange.NewStats.Fum_lost > 0 }) {
+      pTest.Errorf("Stat changes %+v, expected recorded fumbles", recheckResult.StatChanges)
+   }
+}
+
+//--------------------------------------------------------------------------------------------------
+// A stat correction that moves the Bandits' quarterback from 16.73 to 20.73 points in week 1 takes
+// Hot Start from the Icemen (142.77) to the Bandits (144.77).
+//--------------------------------------------------------------------------------------------------
+func TestRecheckWeek(pTest *testing.T) {
+
+   hotStart, _ := GetPrize("Hot Start")
+   mvp, _ := GetPrize("MVP")
+   _, seasonData := newTestSeason(pTest, []ScheduledPrize{{1, hotStart}, {2, mvp}})
+
+   resultsStore := NewResultsStore(pTest.TempDir(), testLeagueId)
+   result, err := resultsStore.FinalizeWeek(seasonData, hotStart, GetWeekSummary(hotStart, seasonData, 1))
+
+   if err != nil {
+      pTest.Fatalf("FinalizeWeek: %s", err.Error())
+   }
+
+   if recheckResult := RecheckWeek(seasonData, hotStart, result, defaultRecheckTolerance) ; recheckResult.Err != nil || recheckResult.HasChanges() || recheckResult.InputChanged {
+      pTest.Errorf("Recheck without a stat correction gave %+v, expected no changes", recheckResult)
+   }
+
+   bandits := &seasonData.mMatchups[1][1]
+   bandits.Starters_points[0] += 4.0
+   bandits.Players_points[bandits.Starters[0]] += 4.0
+
+   recheckResult := RecheckWeek(seasonData, hotStart, result, defaultRecheckTolerance)
+
+   if recheckResult.Err != nil || !recheckResult.InputChanged || !recheckResult.WinnerChanged() || !slices.Equal(recheckResult.NewWinners, []string{"Bandits"}) {
+      pTest.Fatalf("Recheck gave %+v, expected the Bandits to take the win from the Icemen", recheckResult)
+   }
+
+   expectedChanges := []EntryChange{
+      {Owner: "Bandits", OldRank: 2, NewRank: 1, OldScore: 140.77, NewScore: 144.77},
+      {Owner: "Icemen", OldRank: 1, NewRank: 2, OldScore: 142.77, NewScore: 142.77},
+   }
+
+   if len(recheckResult.EntryChanges) != len(expectedChanges) {
+      pTest.Fatalf("Entry changes %+v, expected %+v", recheckResult.EntryChanges, expectedChanges)
+   }
+
+   for idx, expectedChange := range expectedChanges {
+      entryChange := recheckResult.EntryChanges[idx]
+
+      if entryChange.Owner != expectedChange.Owner || entryChange.OldRank != expectedChange.OldRank || entryChange.NewRank != expectedChange.NewRank || math.Abs(entryChange.NewScore - expectedChange.NewScore) > 1e-6 {
+         pTest.Errorf("Entry change %+v, expected %+v", entryChange, expectedChange)
+      }
+   }
+
+   if len(recheckResult.PlayerChanges) != 1 || recheckResult.PlayerChanges[0].Name != "Alex Banditsson" {
+      pTest.Errorf("Player changes %+v, expected Alex Banditsson", recheckResult.PlayerChanges)
+   }
+}
+
+//--------------------------------------------------------------------------------------------------
+// Players and owners that only appear on one side of the recheck are still reported.
+//--------------------------------------------------------------------------------------------------
+func TestRecheckWeekComparesBothWays(pTest *testing.T) {
+
+   hotStart, _ := GetPrize("Hot Start")
+   _, seasonData := newTestSeason(pTest, []ScheduledPrize{{1, hotStart}})
+
+   resultsStore := NewResultsStore(pTest.TempDir(), testLeagueId)
+   result, err := resultsStore.FinalizeWeek(seasonData, hotStart, GetWeekSummary(hotStart, seasonData, 1))
+
+   if err != nil {
+      pTest.Fatalf("FinalizeWeek: %s", err.Error())
+   }
+
+   // The Bandits' quarterback is missing from the stored points, and a team that has since left the
+   // league is only in the stored summary
+   bandits := seasonData.mMatchups[1][1]
+   quarterback := bandits.Starters[0]
+   delete(result.PlayerPoints, quarterback)
+
+   result.Summary.IneligibleEntries = append(result.Summary.IneligibleEntries, PrizeEntry{Owner: "Expansion", Eligibility: Ineligible, Reason: "Bye Week"})
+
+   recheckResult := RecheckWeek(seasonData, hotStart, result, defaultRecheckTolerance)
+
+   if len(recheckResult.PlayerChanges) != 1 || recheckResult.PlayerChanges[0].Player_id != quarterback || recheckResult.PlayerChanges[0].NewPoints != bandits.Players_points[quarterback] {
+      pTest.Errorf("Player changes %+v, expected player %s's new points", recheckResult.PlayerChanges, quarterback)
+   }
+
+   if len(recheckResult.EntryChanges) != 1 || recheckResult.EntryChanges[0].Owner != "Expansion" || recheckResult.EntryChanges[0].NewReason != "Not In The Recomputed Result" {
+      pTest.Errorf("Entry changes %+v, expected the Expansion team to be dropped", recheckResult.EntryChanges)
+   }
+}
@@ -0,0 +1,264 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+)
+
+const defaultResultsDir = "Results"
+
+//--------------------------------------------------------------------------------------------------
+// A finalized week result is locked: later runs report its stored summary instead of recomputing
+// it. Params and TieBreakers record how the prize was configured, so that reconfiguring it reopens
+// the week. InputHash identifies the Sleeper data the summary was computed from, and PlayerPoints
+// and PlayerStats keep every rostered player's points, and stat line for prizes that score stats,
+// at the time so that later stat corrections can be traced to players.
+//--------------------------------------------------------------------------------------------------
+type WeekResult struct {
+   League_id string
+   Week int
+   Prize string
+   Params map[string]any
+   TieBreakers []string
+   Finalized bool
+   FinalizedAt time.Time
+   InputHash string
+   PlayerPoints map[string]float64
+   PlayerStats map[string]PlayerStats
+   Summary WeekSummary
+}
+
+//--------------------------------------------------------------------------------------------------
+// ResultsStore keeps one JSON file per week for a league under <dir>/<league id>.
+//--------------------------------------------------------------------------------------------------
+type ResultsStore struct {
+   mDir string
+   mLeagueId string
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func NewResultsStore(pDir string, pLeagueId string) *ResultsStore {
+   return &ResultsStore{pDir, pLeagueId}
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (store *ResultsStore) getResultPath(pWeek int) string {
+   return filepath.Join(store.mDir, store.mLeagueId, "Week" + strconv.Itoa(pWeek) + ".json")
+}
+
+//--------------------------------------------------------------------------------------------------
+// Load returns the stored result for pWeek, or ErrNotFound if the week has none.
+//--------------------------------------------------------------------------------------------------
+func (store *ResultsStore) Load(pWeek int) (WeekResult, error) {
+
+   resultPath := store.getResultPath(pWeek)
+   resultBytes, err := os.ReadFile(resultPath)
+
+   if errors.Is(err, fs.ErrNotExist) {
+      return WeekResult{}, fmt.Errorf("Load: Week %d has no stored result: %w", pWeek, ErrNotFound)
+   }
+
+   if err != nil {
+      return WeekResult{}, err
+   }
+
+   var result WeekResult
+   err = json.Unmarshal(resultBytes, &result)
+
+   if err != nil {
+      return WeekResult{}, fmt.Errorf("Load: Failed to decode %s: %w", resultPath, err)
+   }
+
+   return result, nil
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (store *ResultsStore) Save(pResult WeekResult) error {
+
+   resultBytes, err := json.MarshalIndent(pResult, "", "   ")
+
+   if err != nil {
+      return err
+   }
+
+   return writeFileAtomically(store.getResultPath(pResult.Week), resultBytes)
+}
+
+//--------------------------------------------------------------------------------------------------
+// GetLockedResults loads the finalized results of the scheduled weeks. A result finalized for a
+// different prize than the one now scheduled, or for the same prize with other params or
+// tie-breakers, does not lock its week.
+//--------------------------------------------------------------------------------------------------
+func (store *ResultsStore) GetLockedResults(pPrizeSchedule []ScheduledPrize) (map[int]WeekResult, error) {
+
+   lockedResults := make(map[int]WeekResult)
+   var errs []error
+
+   for _, scheduledPrize := range pPrizeSchedule {
+
+      result, err := store.Load(scheduledPrize.mWeek)
+
+      if errors.Is(err, ErrNotFound) {
+         continue
+      }
+
+      if err != nil {
+         errs = append(errs, err)
+         continue
+      }
+
+      if result.Finalized && result.isForPrize(scheduledPrize.mPrize) {
+         lockedResults[scheduledPrize.mWeek] = result
+      }
+   }
+
+   return lockedResults, errors.Join(errs...)
+}
+
+//--------------------------------------------------------------------------------------------------
+// Params are compared as JSON, which is how they are stored.
+//--------------------------------------------------------------------------------------------------
+func (result WeekResult) isForPrize(pPrize Prize) bool {
+
+   if result.Prize != pPrize.Name() || !slices.Equal(result.TieBreakers, getTieBreakerNames(pPrize.TieBreakers())) {
+      return false
+   }
+
+   storedParams, err := json.Marshal(result.Params)
+
+   if err != nil {
+      return false
+   }
+
+   params, err := json.Marshal(pPrize.Params())
+
+   return err == nil && bytes.Equal(storedParams, params)
+}
+
+//--------------------------------------------------------------------------------------------------
+// FinalizeWeek stores pSummary as the locked result of its week.
+//--------------------------------------------------------------------------------------------------
+func (store *ResultsStore) FinalizeWeek(pSeasonData SeasonData, pPrize Prize, pSummary WeekSummary) (WeekResult, error) {
+
+   if pSummary.Err != nil {
+      return WeekResult{}, fmt.Errorf("FinalizeWeek: Week %d failed: %w", pSummary.Week, pSummary.Err)
+   }
+
+   inputHash, err := pSeasonData.GetWeekInputHash(pSummary.Week, pPrize.RequiredData())
+
+   if err != nil {
+      return WeekResult{}, err
+   }
+
+   var result WeekResult
+   result.League_id = store.mLeagueId
+   result.Week = pSummary.Week
+   result.Prize = pPrize.Name()
+   result.Params = pPrize.Params()
+   result.TieBreakers = getTieBreakerNames(pPrize.TieBreakers())
+   result.Finalized = true
+   result.FinalizedAt = time.Now()
+   result.InputHash = inputHash
+   result.PlayerPoints = pSeasonData.GetWeekPlayerPoints(pSummary.Week)
+
+   if pPrize.RequiredData() & PrizeDataPlayerStats != 0 {
+      result.PlayerStats = pSeasonData.GetWeekPlayerStats(pSummary.Week)
+   }
+   result.Summary = pSummary
+   result.Summary.Finalized = true
+
+   // Rollovers depend on the other weeks, so they are applied again on every run
+   result.Summary.Rollover = ""
+   result.Summary.RolledOverWeeks = nil
+
+   return result, store.Save(result)
+}
+
+//--------------------------------------------------------------------------------------------------
+// GetOpenPrizes returns the scheduled prizes whose weeks are not locked.
+//--------------------------------------------------------------------------------------------------
+func GetOpenPrizes(pPrizeSchedule []ScheduledPrize, pLockedResults map[int]WeekResult) []ScheduledPrize {
+   var openPrizes []ScheduledPrize
+
+   for _, scheduledPrize := range pPrizeSchedule {
+      if _, isLocked := pLockedResults[scheduledPrize.mWeek] ; !isLocked {
+         openPrizes = append(openPrizes, scheduledPrize)
+      }
+   }
+
+   return openPrizes
+}
+
+//--------------------------------------------------------------------------------------------------
+// GetWeekInputHash hashes the week's matchups, and its player stats for prizes that score them.
+// Projections and player details are left out, since Sleeper keeps updating them after the week.
+//--------------------------------------------------------------------------------------------------
+func (seasonData SeasonData) GetWeekInputHash(pWeek int, pRequiredData PrizeData) (string, error) {
+
+   weekData, err := seasonData.GetWeekData(pWeek, pRequiredData & PrizeDataPlayerStats)
+
+   if err != nil {
+      return "", err
+   }
+
+   inputBytes, err := json.Marshal(struct {
+      Matchups []Matchup
+      PlayerStats map[string]PlayerStats
+   }{weekData.mMatchups, weekData.mPlayerStats})
+
+   if err != nil {
+      return "", err
+   }
+
+   inputHash := sha256.Sum256(inputBytes)
+
+   return hex.EncodeToString(inputHash[:]), nil
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (seasonData SeasonData) GetWeekPlayerPoints(pWeek int) map[string]float64 {
+   playerPoints := make(map[string]float64)
+
+   for _, matchup := range seasonData.mMatchups[pWeek] {
+      for playerId, points := range matchup.Players_points {
+         playerPoints[playerId] = points
+      }
+   }
+
+   return playerPoints
+}
+
+//--------------------------------------------------------------------------------------------------
+// GetWeekPlayerStats returns the stat lines of the week's rostered players.
+//--------------------------------------------------------------------------------------------------
+func (seasonData SeasonData) GetWeekPlayerStats(pWeek int) map[string]PlayerStats {
+   playerStats := make(map[string]PlayerStats)
+
+   for _, matchup := range seasonData.mMatchups[pWeek] {
+      for _, playerId := range matchup.Players {
+         if stats, hasStats := seasonData.mPlayerStats[pWeek][playerId] ; hasStats {
+            playerStats[playerId] = stats
+         }
+      }
+   }
+
+   return playerStats
+}
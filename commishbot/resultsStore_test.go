@@ -0,0 +1,83 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func TestResultsStore(pTest *testing.T) {
+
+   hotStart, _ := GetPrize("Hot Start")
+   butterfingers, _ := GetPrize("Butterfingers")
+   prizeSchedule := []ScheduledPrize{{1, hotStart}, {12, butterfingers}}
+   _, seasonData := newTestSeason(pTest, prizeSchedule)
+
+   resultsStore := NewResultsStore(pTest.TempDir(), testLeagueId)
+
+   for _, scheduledPrize := range prizeSchedule {
+      summary := GetWeekSummary(scheduledPrize.mPrize, seasonData, scheduledPrize.mWeek)
+      _, err := resultsStore.FinalizeWeek(seasonData, scheduledPrize.mPrize, summary)
+
+      if err != nil {
+         pTest.Fatalf("Week %d: FinalizeWeek: %s", scheduledPrize.mWeek, err.Error())
+      }
+   }
+
+   lockedResults, err := resultsStore.GetLockedResults(prizeSchedule)
+
+   if err != nil || len(lockedResults) != 2 || len(GetOpenPrizes(prizeSchedule, lockedResults)) != 0 {
+      pTest.Fatalf("Locked results %+v (%v), expected weeks 1 and 12", lockedResults, err)
+   }
+
+   expectedSummary := GetWeekSummary(butterfingers, seasonData, 12)
+   expectedSummary.Finalized = true
+
+   if !reflect.DeepEqual(lockedResults[12].Summary, expectedSummary) {
+      pTest.Errorf("Stored summary %+v, expected %+v", lockedResults[12].Summary, expectedSummary)
+   }
+
+   // Scheduling another prize reopens the week
+   lockedResults, _ = resultsStore.GetLockedResults([]ScheduledPrize{{1, butterfingers}, {12, butterfingers}})
+
+   if _, isLocked := lockedResults[1] ; isLocked {
+      pTest.Errorf("Week 1 is locked for Hot Start after rescheduling it with Butterfingers")
+   }
+
+   // So do other tie-breakers or params for the same prize
+   reconfiguredResults, _ := resultsStore.GetLockedResults([]ScheduledPrize{{1, WithTieBreakers(hotStart, []TieBreaker{TieBreakerBenchPoints})}})
+
+   if _, isLocked := reconfiguredResults[1] ; isLocked {
+      pTest.Errorf("Week 1 is locked after changing Hot Start's tie-breakers")
+   }
+
+   blackjack := MakeBlackjackPrize(21.0)
+   err = resultsStore.Save(WeekResult{League_id: testLeagueId, Week: 2, Prize: blackjack.Name(), Params: blackjack.Params(), TieBreakers: getTieBreakerNames(blackjack.TieBreakers()), Finalized: true})
+
+   if err != nil {
+      pTest.Fatalf("Save: %s", err.Error())
+   }
+
+   for _, target := range []float64{21.0, 25.0} {
+      reconfiguredResults, _ = resultsStore.GetLockedResults([]ScheduledPrize{{2, MakeBlackjackPrize(target)}})
+
+      if _, isLocked := reconfiguredResults[2] ; isLocked != (target == 21.0) {
+         pTest.Errorf("Week 2 Blackjack with a target of %.0f locked %t, expected %t", target, isLocked, target == 21.0)
+      }
+   }
+
+   // The input hash changes with the week's scores
+   inputHash := lockedResults[12].InputHash
+
+   if sameHash, _ := seasonData.GetWeekInputHash(12, butterfingers.RequiredData()) ; sameHash != inputHash {
+      pTest.Errorf("Input hash %q changed to %q without a change in the data", inputHash, sameHash)
+   }
+
+   seasonData.mMatchups[12][0].Players_points[seasonData.mMatchups[12][0].Starters[0]] += 1.0
+
+   if changedHash, _ := seasonData.GetWeekInputHash(12, butterfingers.RequiredData()) ; changedHash == inputHash || inputHash == "" {
+      pTest.Errorf("Input hash %q did not change with the week's scores", inputHash)
+   }
+}
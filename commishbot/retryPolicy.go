@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"time"
+)
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type RetryPolicy struct {
+   MaxAttempts int
+   BaseDelay time.Duration
+   MaxDelay time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{MaxAttempts: 4, BaseDelay: 500 * time.Millisecond, MaxDelay: 10 * time.Second}
+
+//--------------------------------------------------------------------------------------------------
+// Exponential backoff with full jitter: the delay is drawn uniformly from [0, BaseDelay * 2^attempt]
+// capped at MaxDelay. A server supplied Retry-After takes precedence when it is longer.
+//--------------------------------------------------------------------------------------------------
+func (policy RetryPolicy) getDelay(pAttempt int, pErr error) time.Duration {
+
+   maxDelay := policy.BaseDelay << pAttempt
+
+   if maxDelay <= 0 || maxDelay > policy.MaxDelay {
+      maxDelay = policy.MaxDelay
+   }
+
+   delay := time.Duration(0)
+
+   if maxDelay > 0 {
+      delay = rand.N(maxDelay)
+   }
+
+   var sleeperError *SleeperError
+
+   if errors.As(pErr, &sleeperError) && sleeperError.RetryAfter > delay {
+      delay = sleeperError.RetryAfter
+   }
+
+   return delay
+}
+
+//--------------------------------------------------------------------------------------------------
+// Only failures that may pass are retried: transport errors, timeouts, 429 and 5xx replies.
+//--------------------------------------------------------------------------------------------------
+func isRetryableError(pContext context.Context, pErr error) bool {
+
+   if pContext.Err() != nil {
+      return false
+   }
+
+   var sleeperError *SleeperError
+
+   if !errors.As(pErr, &sleeperError) {
+      return false
+   }
+
+   if errors.Is(sleeperError.Kind, ErrRateLimited) || sleeperError.StatusCode >= http.StatusInternalServerError {
+      return true
+   }
+
+   return errors.Is(sleeperError.Kind, ErrRequest) && isTransportError(sleeperError.Err)
+}
+
+//--------------------------------------------------------------------------------------------------
+// A request that could not be built, or that used an unsupported scheme, fails the same way every
+// time, whereas a failed connection, a timeout or a dropped response may not.
+//--------------------------------------------------------------------------------------------------
+func isTransportError(pErr error) bool {
+
+   var netError net.Error
+
+   if errors.As(pErr, &netError) && netError.Timeout() {
+      return true
+   }
+
+   var opError *net.OpError
+
+   return errors.As(pErr, &opError) || errors.Is(pErr, io.EOF) || errors.Is(pErr, io.ErrUnexpectedEOF)
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func sleepContext(pContext context.Context, pDelay time.Duration) error {
+
+   timer := time.NewTimer(pDelay)
+   defer timer.Stop()
+
+   select {
+   case <-timer.C:
+      return nil
+   case <-pContext.Done():
+      return pContext.Err()
+   }
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+//--------------------------------------------------------------------------------------------------
+// newTestRetryClient returns a client for a server that answers the first request with
+// pFirstStatus, after sleeping for pFirstDelay, and every later request with a 200.
+//--------------------------------------------------------------------------------------------------
+func newTestRetryClient(pTest *testing.T, pFirstStatus int, pFirstDelay time.Duration) (*SleeperClient, *atomic.Int32) {
+
+   var requestCount atomic.Int32
+
+   server := httptest.NewServer(http.HandlerFunc(func(pWriter http.ResponseWriter, pRequest *http.Request) {
+
+      if requestCount.Add(1) == 1 {
+         time.Sleep(pFirstDelay)
+         pWriter.WriteHeader(pFirstStatus)
+         return
+      }
+
+      pWriter.Write([]byte("{}"))
+   }))
+
+   pTest.Cleanup(server.Close)
+
+   client := NewSleeperClient(server.URL, server.URL, server.Client())
+   client.SetRateLimiter(nil)
+   client.mRetryPolicy = RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+   client.mRequestTimeout = 50 * time.Millisecond
+
+   return client, &requestCount
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func TestRetryPolicy(pTest *testing.T) {
+
+   testCases := []struct {
+      name string
+      firstStatus int
+      firstDelay time.Duration
+      expectedRequests int32
+   }{
+      {"Server error", http.StatusServiceUnavailable, 0, 2},
+      {"Rate limited", http.StatusTooManyRequests, 0, 2},
+      {"Timeout", http.StatusOK, 200 * time.Millisecond, 2},
+      {"Bad request", http.StatusBadRequest, 0, 1},
+      {"Not found", http.StatusNotFound, 0, 1},
+   }
+
+   for _, testCase := range testCases {
+
+      client, requestCount := newTestRetryClient(pTest, testCase.firstStatus, testCase.firstDelay)
+      _, err := client.getHttpResponse(context.Background(), client.mBaseUrl + "/state/nfl", time.Hour)
+
+      if requestCount.Load() != testCase.expectedRequests {
+         pTest.Errorf("%s: %d requests (%v), expected %d", testCase.name, requestCount.Load(), err, testCase.expectedRequests)
+      }
+
+      if testCase.expectedRequests == 2 && err != nil {
+         pTest.Errorf("%s: The retry failed: %s", testCase.name, err.Error())
+      }
+   }
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func TestRequestErrorsAreNotRetried(pTest *testing.T) {
+
+   client, _ := newTestRetryClient(pTest, http.StatusOK, 0)
+
+   for _, requestUrl := range []string{"http://%zz/state/nfl", "ftp://localhost/state/nfl"} {
+
+      _, err := client.fetch(context.Background(), requestUrl, HttpCacheEntry{})
+
+      if !errors.Is(err, ErrRequest) || isRetryableError(context.Background(), err) {
+         pTest.Errorf("Fetching %s gave %v, expected a request error that is not retried", requestUrl, err)
+      }
+   }
+
+   closedServer := httptest.NewServer(http.NotFoundHandler())
+   closedServer.Close()
+
+   _, err := client.fetch(context.Background(), closedServer.URL + "/state/nfl", HttpCacheEntry{})
+
+   if !errors.Is(err, ErrRequest) || !isRetryableError(context.Background(), err) {
+      pTest.Errorf("Fetching from a closed server gave %v, expected a request error that is retried", err)
+   }
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+)
+
+//--------------------------------------------------------------------------------------------------
+// RolloverPolicy decides what happens to a prize that no team was eligible for.
+//--------------------------------------------------------------------------------------------------
+type RolloverPolicy string
+
+const (
+   RolloverForfeit RolloverPolicy = "Forfeit"
+   RolloverNextWeek RolloverPolicy = "NextWeek"
+)
+
+const defaultRolloverPolicy = RolloverForfeit
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func ParseRolloverPolicy(pName string) (RolloverPolicy, error) {
+
+   switch makePrizeKey(pName) {
+   case "":
+      return defaultRolloverPolicy, nil
+   case makePrizeKey(string(RolloverForfeit)):
+      return RolloverForfeit, nil
+   case makePrizeKey(string(RolloverNextWeek)):
+      return RolloverNextWeek, nil
+   }
+
+   return "", fmt.Errorf("unknown rollover policy %q", pName)
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (policy RolloverPolicy) Describe() string {
+
+   if policy == RolloverNextWeek {
+      return "the prize rolls over to the next week with a winner"
+   }
+
+   return "the prize is forfeited"
+}
+
+//--------------------------------------------------------------------------------------------------
+// ApplyRolloverPolicy records the policy on every summary without a winner and, for RolloverNextWeek,
+// carries those weeks to the next summary in pSummaries that does have one. Summaries that failed to
+// load neither roll over nor receive a rollover. pSummaries must be in week order.
+//--------------------------------------------------------------------------------------------------
+func ApplyRolloverPolicy(pSummaries []WeekSummary, pPolicy RolloverPolicy) {
+
+   var pendingWeeks []int
+
+   for idx := range pSummaries {
+
+      summary := &pSummaries[idx]
+
+      if summary.Err != nil {
+         continue
+      }
+
+      if !summary.HasWinner() {
+         summary.Rollover = pPolicy
+
+         if pPolicy == RolloverNextWeek {
+            pendingWeeks = append(pendingWeeks, summary.Week)
+         }
+
+         continue
+      }
+
+      summary.RolledOverWeeks = pendingWeeks
+      pendingWeeks = nil
+   }
+}
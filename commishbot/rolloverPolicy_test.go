@@ -0,0 +1,48 @@
+package main
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func TestApplyRolloverPolicy(pTest *testing.T) {
+
+   winner := PrizeEntries{{Score: 1.0, Owner: "Aces", Rank: 1}}
+
+   makeSummaries := func() []WeekSummary {
+      return []WeekSummary{
+         {Week: 1, PrizeEntries: winner},
+         {Week: 2},
+         {Week: 3, Err: errors.New("load failed")},
+         {Week: 4},
+         {Week: 5, PrizeEntries: winner},
+         {Week: 6},
+      }
+   }
+
+   summaries := makeSummaries()
+   ApplyRolloverPolicy(summaries, RolloverNextWeek)
+
+   if rolledOverWeeks := summaries[4].RolledOverWeeks ; !slices.Equal(rolledOverWeeks, []int{2, 4}) {
+      pTest.Errorf("Week 5: Rolled over weeks %v, expected [2 4]", rolledOverWeeks)
+   }
+
+   if summaries[5].Rollover != RolloverNextWeek || summaries[2].Rollover != "" {
+      pTest.Errorf("Rollover policies %q and %q, expected %q and none", summaries[5].Rollover, summaries[2].Rollover, RolloverNextWeek)
+   }
+
+   summaries = makeSummaries()
+   ApplyRolloverPolicy(summaries, RolloverForfeit)
+
+   if rolledOverWeeks := summaries[4].RolledOverWeeks ; len(rolledOverWeeks) != 0 {
+      pTest.Errorf("Week 5: Rolled over weeks %v, expected none", rolledOverWeeks)
+   }
+
+   if summaries[1].Rollover != RolloverForfeit {
+      pTest.Errorf("Week 2: Rollover policy %q, expected %q", summaries[1].Rollover, RolloverForfeit)
+   }
+}
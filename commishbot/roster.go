@@ -1,6 +1,6 @@
 package main
 
-import "encoding/json"
+import "context"
 
 //--------------------------------------------------------------------------------------------------
 //
@@ -10,25 +10,43 @@ type Roster struct {
    Roster_id int
    Players []string
    Starters []string
+   Reserve []string
+   Taxi []string
+   Settings RosterSettings
 }
 
 //--------------------------------------------------------------------------------------------------
 //
 //--------------------------------------------------------------------------------------------------
-func GetRostersData(pLeagueId string) string {
-   return GetHttpResponse("https://api.sleeper.app/v1/league/" + pLeagueId + "/rosters")
+type RosterSettings struct {
+   Total_moves int
+   Division int
 }
 
 //--------------------------------------------------------------------------------------------------
 //
 //--------------------------------------------------------------------------------------------------
-func GetRosters(pLeagueId string) []Roster {
+func (client *SleeperClient) GetRostersData(pContext context.Context, pLeagueId string) (string, error) {
+   return client.getHttpResponse(pContext, client.mBaseUrl + "/league/" + pLeagueId + "/rosters", defaultCacheTtl)
+}
 
-   rostersData := GetRostersData(pLeagueId)
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (client *SleeperClient) GetRosters(pContext context.Context, pLeagueId string) ([]Roster, error) {
+
+   rostersData, err := client.GetRostersData(pContext, pLeagueId)
+
+   if err != nil {
+      return nil, err
+   }
 
    var rosters []Roster
-   err := json.Unmarshal([]byte(rostersData), &rosters)
-   check(err)
+   err = unmarshalSleeperData(rostersData, "league " + pLeagueId + " rosters", &rosters)
+
+   if err != nil {
+      return nil, err
+   }
 
-   return rosters
+   return rosters, nil
 }
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sort"
+)
+
+//--------------------------------------------------------------------------------------------------
+// Week awards go to the best single prize entry of any week, and season awards to the best total or
+// average of each team's weekly entries.
+//--------------------------------------------------------------------------------------------------
+type AwardType int
+
+const (
+   AwardBestWeek AwardType = iota
+   AwardSeasonTotal
+   AwardSeasonAverage
+)
+
+//--------------------------------------------------------------------------------------------------
+// Week is zero for awards that cover the whole season.
+//--------------------------------------------------------------------------------------------------
+type SeasonAward struct {
+   Name string
+   Criteria string
+   Owner string
+   Week int
+   Score float64
+   Evidence PrizeEvidence
+   Error string `json:",omitempty"`
+   Err error `json:"-"`
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type SeasonAwards struct {
+   ThroughWeek int
+   Awards []SeasonAward
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type seasonAwardDefinition struct {
+   mName string
+   mPrize Prize
+   mType AwardType
+}
+
+//--------------------------------------------------------------------------------------------------
+// The weekly awards score each week with an existing prize. Lowest Score reuses the Hot Start
+// scoring in the opposite order.
+//--------------------------------------------------------------------------------------------------
+var seasonAwardDefinitions = []seasonAwardDefinition{
+   {"Highest Score", HotStartPrize{PrizeInfo{"Hot Start", "Highest Starting Team Score", 0, SortDescending, nil}}, AwardBestWeek},
+   {"Lowest Score", HotStartPrize{PrizeInfo{"Cold Start", "Lowest Starting Team Score", 0, SortAscending, nil}}, AwardBestWeek},
+   {"Biggest Blowout", BiggestBlowoutPrize{PrizeInfo{"Biggest Blowout", "Largest Margin Of Victory", 0, SortDescending, nil}}, AwardBestWeek},
+   {"Narrowest Win", PhotoFinishPrize{PrizeInfo{"Photo Finish", "Closest Margin Of Victory", 0, SortAscending, nil}}, AwardBestWeek},
+   {"Bench Warmers", BenchWarmersPrize{PrizeInfo{"Bench Warmers", "Most Bench Points Left Behind", 0, SortDescending, nil}}, AwardSeasonTotal},
+   {"Best Manager", BestManagerPrize{PrizeInfo{"Best Manager", "Best Average Lineup Efficiency", PrizeDataPlayers, SortDescending, nil}}, AwardSeasonAverage},
+}
+
+//--------------------------------------------------------------------------------------------------
+// GetSeasonAwardsRequiredData returns the data that has to be loaded for every week of the season.
+//--------------------------------------------------------------------------------------------------
+func GetSeasonAwardsRequiredData() PrizeData {
+   var requiredData PrizeData
+
+   for _, definition := range seasonAwardDefinitions {
+      requiredData |= definition.mPrize.RequiredData()
+   }
+
+   return requiredData
+}
+
+//--------------------------------------------------------------------------------------------------
+// GetSeasonAwards hands out the season awards over weeks 1 through pThroughWeek, whose matchups must
+// be loaded. A week award tied across weeks goes to the earliest week. The points for and against
+// awards come from the standings.
+//--------------------------------------------------------------------------------------------------
+func GetSeasonAwards(pSeasonData SeasonData, pThroughWeek int) SeasonAwards {
+
+   var seasonAwards SeasonAwards
+   seasonAwards.ThroughWeek = pThroughWeek
+
+   standings, err := GetStandings(pSeasonData, pThroughWeek)
+   pointsFor := SeasonAward{Name: "Most Points For", Criteria: "Most Total Points Scored", Err: err}
+   pointsAgainst := SeasonAward{Name: "Unluckiest", Criteria: "Most Total Points Scored Against", Err: err}
+
+   if err == nil {
+      for _, team := range standings.Teams {
+         if pointsFor.Owner == "" || team.PointsFor > pointsFor.Score {
+            pointsFor.Owner, pointsFor.Score = team.Owner, team.PointsFor
+         }
+
+         if pointsAgainst.Owner == "" || team.PointsAgainst > pointsAgainst.Score {
+            pointsAgainst.Owner, pointsAgainst.Score = team.Owner, team.PointsAgainst
+         }
+      }
+   }
+
+   seasonAwards.Awards = append(seasonAwards.Awards, pointsFor, pointsAgainst)
+
+   for _, definition := range seasonAwardDefinitions {
+      seasonAwards.Awards = append(seasonAwards.Awards, getSeasonAward(pSeasonData, pThroughWeek, definition))
+   }
+
+   // Error repeats Err for JSON output
+   for idx := range seasonAwards.Awards {
+      if seasonAwards.Awards[idx].Err != nil {
+         seasonAwards.Awards[idx].Error = seasonAwards.Awards[idx].Err.Error()
+      }
+   }
+
+   return seasonAwards
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func getSeasonAward(pSeasonData SeasonData, pThroughWeek int, pDefinition seasonAwardDefinition) SeasonAward {
+
+   var award SeasonAward
+   award.Name = pDefinition.mName
+   award.Criteria = pDefinition.mPrize.Criteria()
+
+   sortOrder := pDefinition.mPrize.SortOrder()
+   teamTotals := make(map[string]float64)
+   teamWeeks := make(map[string]int)
+   var owners []string
+
+   for week := 1 ; week <= pThroughWeek ; week++ {
+
+      summary := GetWeekSummary(pDefinition.mPrize, pSeasonData, week)
+
+      if summary.Err != nil {
+         award.Err = fmt.Errorf("GetSeasonAwards: Week %d: %w", week, summary.Err)
+         return award
+      }
+
+      for _, prizeEntry := range summary.PrizeEntries {
+
+         if pDefinition.mType == AwardBestWeek {
+            if award.Owner == "" || compareScores(prizeEntry.Score, award.Score, sortOrder) < 0 {
+               award.Owner = prizeEntry.Owner
+               award.Week = week
+               award.Score = prizeEntry.Score
+               award.Evidence = prizeEntry.Evidence
+            }
+
+            continue
+         }
+
+         if _, hasOwner := teamWeeks[prizeEntry.Owner] ; !hasOwner {
+            owners = append(owners, prizeEntry.Owner)
+         }
+
+         teamTotals[prizeEntry.Owner] += prizeEntry.Score
+         teamWeeks[prizeEntry.Owner]++
+      }
+   }
+
+   sort.Strings(owners)
+
+   for _, owner := range owners {
+
+      score := teamTotals[owner]
+
+      if pDefinition.mType == AwardSeasonAverage {
+         score /= float64(teamWeeks[owner])
+      }
+
+      if award.Owner == "" || compareScores(score, award.Score, sortOrder) < 0 {
+         award.Owner = owner
+         award.Score = score
+      }
+   }
+
+   return award
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (seasonAwards SeasonAwards) Print() {
+
+   log.Printf("Season Awards Through Week %d", seasonAwards.ThroughWeek)
+
+   for _, award := range seasonAwards.Awards {
+
+      if award.Err != nil {
+         log.Printf("   %s - %s: %s", award.Name, award.Criteria, award.Err.Error())
+         continue
+      }
+
+      if award.Owner == "" {
+         log.Printf("   %s - %s: No winner", award.Name, award.Criteria)
+         continue
+      }
+
+      if award.Week != 0 {
+         log.Printf("   %s - %s: %s, Week %d, Score: %.2f", award.Name, award.Criteria, award.Owner, award.Week, award.Score)
+      } else {
+         log.Printf("   %s - %s: %s, Score: %.2f", award.Name, award.Criteria, award.Owner, award.Score)
+      }
+
+      printEvidence(award.Evidence, "      ")
+   }
+}
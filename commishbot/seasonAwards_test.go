@@ -0,0 +1,54 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func TestSeasonAwards(pTest *testing.T) {
+
+   _, seasonData := newTestSeason(pTest, makeDefaultTestSchedule(pTest))
+
+   expectedAwards := []struct {
+      name string
+      owner string
+      week int
+      score float64
+   }{
+      {"Most Points For", "Giants", 0, 1774.31},
+      {"Unluckiest", "Hornets", 0, 1750.24},
+      {"Highest Score", "Giants", 8, 168.51},
+      {"Lowest Score", "Icemen", 2, 59.35},
+      {"Biggest Blowout", "Giants", 8, 102.45},
+      {"Narrowest Win", "Giants", 1, 0.61},
+      {"Bench Warmers", "Giants", 0, 927.61},
+      {"Best Manager", "Jaguars", 0, 82.51},
+   }
+
+   seasonAwards := GetSeasonAwards(seasonData, 14)
+
+   if len(seasonAwards.Awards) != len(expectedAwards) {
+      pTest.Fatalf("%d awards, expected %d", len(seasonAwards.Awards), len(expectedAwards))
+   }
+
+   for idx, expectedAward := range expectedAwards {
+
+      award := seasonAwards.Awards[idx]
+
+      if award.Err != nil {
+         pTest.Errorf("%s: %s", expectedAward.name, award.Err.Error())
+         continue
+      }
+
+      if award.Name != expectedAward.name || award.Owner != expectedAward.owner || award.Week != expectedAward.week || math.Abs(award.Score - expectedAward.score) > 0.005 {
+         pTest.Errorf("%s %s week %d %.2f, expected %s %s week %d %.2f", award.Name, award.Owner, award.Week, award.Score, expectedAward.name, expectedAward.owner, expectedAward.week, expectedAward.score)
+      }
+   }
+
+   if blowout := seasonAwards.Awards[4] ; blowout.Evidence.Opponent != "Icemen" {
+      pTest.Errorf("Biggest blowout opponent %q, expected Icemen", blowout.Evidence.Opponent)
+   }
+}
@@ -0,0 +1,307 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+const defaultLoaderParallelism = 8
+
+//--------------------------------------------------------------------------------------------------
+// RosterWeek is the week the rosters' reserve and taxi squads describe, which Sleeper only reports
+// for the current week, or 0 when no week is being played.
+//--------------------------------------------------------------------------------------------------
+type SeasonDataOptions struct {
+   MatchupWeeks []int
+   StatsWeeks []int
+   ProjectionWeeks []int
+   LoadPlayers bool
+   LoadBrackets bool
+   Parallelism int
+   GameTimes map[int]WeekGameTimes
+   RosterWeek int
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type SeasonData struct {
+   mLeagueInfo LeagueInfo
+   mYear int
+   mMatchups map[int][]Matchup
+   mPlayerStats map[int]map[string]PlayerStats
+   mPlayers map[string]Player
+   mProjections *ProjectionStore
+   mGameTimes map[int]WeekGameTimes
+   mRosterWeek int
+   mBrackets map[BracketType]Bracket
+
+   mWeekErrs map[int]error
+   mPlayersErr error
+   mBracketsErr error
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func MakeSeasonDataOptions(pPrizeSchedule []ScheduledPrize) SeasonDataOptions {
+   var options SeasonDataOptions
+
+   for _, scheduledPrize := range pPrizeSchedule {
+
+      requiredData := scheduledPrize.mPrize.RequiredData()
+      options.MatchupWeeks = append(options.MatchupWeeks, scheduledPrize.mWeek)
+
+      if requiredData & PrizeDataPlayers != 0 {
+         options.LoadPlayers = true
+      }
+
+      if requiredData & PrizeDataPlayerStats != 0 {
+         options.StatsWeeks = append(options.StatsWeeks, scheduledPrize.mWeek)
+      }
+
+      if requiredData & PrizeDataProjections != 0 {
+         options.ProjectionWeeks = append(options.ProjectionWeeks, scheduledPrize.mWeek)
+      }
+
+      if requiredData & PrizeDataBrackets != 0 {
+         options.LoadBrackets = true
+      }
+   }
+
+   return options
+}
+
+//--------------------------------------------------------------------------------------------------
+// LoadSeasonData fetches everything the prizes need up front, running independent requests in
+// parallel. Failing to load the league, its users or its rosters is fatal and cancels the remaining
+// requests. Failures for a single week or for the player data are recorded instead, so that only the
+// summaries that depend on them report an error.
+//--------------------------------------------------------------------------------------------------
+func LoadSeasonData(pContext context.Context, pClient *SleeperClient, pLeagueId string, pYear int, pOptions SeasonDataOptions) (SeasonData, error) {
+
+   var seasonData SeasonData
+   seasonData.mYear = pYear
+   seasonData.mMatchups = make(map[int][]Matchup)
+   seasonData.mPlayerStats = make(map[int]map[string]PlayerStats)
+   seasonData.mWeekErrs = make(map[int]error)
+   seasonData.mBrackets = make(map[BracketType]Bracket)
+   seasonData.mGameTimes = pOptions.GameTimes
+   seasonData.mRosterWeek = pOptions.RosterWeek
+
+   parallelism := pOptions.Parallelism
+
+   if parallelism <= 0 {
+      parallelism = defaultLoaderParallelism
+   }
+
+   var mutex sync.Mutex
+
+   recordWeekErr := func(pWeek int, pErr error) {
+      mutex.Lock()
+      defer mutex.Unlock()
+
+      if seasonData.mWeekErrs[pWeek] == nil {
+         seasonData.mWeekErrs[pWeek] = pErr
+      }
+   }
+
+   group, _ := NewTaskGroup(pContext, parallelism)
+   seasonData.mLeagueInfo.addLoadTasks(group, pClient, pLeagueId)
+
+   for _, week := range makeUniqueWeeks(pOptions.MatchupWeeks) {
+      group.Go(func(pContext context.Context) error {
+         matchups, err := pClient.GetMatchups(pContext, pLeagueId, week)
+
+         if err != nil {
+            recordWeekErr(week, err)
+            return nil
+         }
+
+         mutex.Lock()
+         seasonData.mMatchups[week] = matchups
+         mutex.Unlock()
+
+         return nil
+      })
+   }
+
+   for _, week := range makeUniqueWeeks(pOptions.StatsWeeks) {
+      group.Go(func(pContext context.Context) error {
+         playerStats, err := pClient.GetPlayerStats(pContext, SeasonTypeRegular, pYear, week)
+
+         if err != nil {
+            recordWeekErr(week, err)
+            return nil
+         }
+
+         mutex.Lock()
+         seasonData.mPlayerStats[week] = playerStats
+         mutex.Unlock()
+
+         return nil
+      })
+   }
+
+   if pOptions.LoadPlayers {
+      group.Go(func(pContext context.Context) error {
+         seasonData.mPlayers, seasonData.mPlayersErr = pClient.GetPlayers(pContext)
+         return nil
+      })
+   }
+
+   if pOptions.LoadBrackets {
+      for _, bracketType := range []BracketType{WinnersBracket, LosersBracket} {
+         group.Go(func(pContext context.Context) error {
+            bracket, err := pClient.GetBracket(pContext, pLeagueId, bracketType)
+
+            mutex.Lock()
+            defer mutex.Unlock()
+
+            if err != nil {
+               seasonData.mBracketsErr = err
+               return nil
+            }
+
+            seasonData.mBrackets[bracketType] = bracket
+
+            return nil
+         })
+      }
+   }
+
+   err := group.Wait()
+
+   if err != nil {
+      return SeasonData{}, err
+   }
+
+   if pContext.Err() != nil {
+      return SeasonData{}, pContext.Err()
+   }
+
+   seasonData.mLeagueInfo.mDisplayNames = MakeDisplayNamesMap(seasonData.mLeagueInfo.mLeagueUsers)
+   seasonData.mProjections = NewProjectionStore(pClient, pYear, parallelism)
+
+   for _, week := range makeUniqueWeeks(pOptions.ProjectionWeeks) {
+
+      var starters []string
+
+      for _, matchup := range seasonData.mMatchups[week] {
+         for _, starter := range matchup.Starters {
+            if starter != EmptyPlayerId {
+               starters = append(starters, starter)
+            }
+         }
+      }
+
+      err = seasonData.mProjections.Load(pContext, starters)
+
+      if err != nil {
+         recordWeekErr(week, err)
+      }
+   }
+
+   return seasonData, nil
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (seasonData SeasonData) GetWeekData(pWeek int, pRequiredData PrizeData) (WeekData, error) {
+
+   if err := seasonData.mWeekErrs[pWeek] ; err != nil {
+      return WeekData{}, err
+   }
+
+   matchups, hasMatchups := seasonData.mMatchups[pWeek]
+
+   if !hasMatchups {
+      return WeekData{}, fmt.Errorf("GetWeekData: Week %d matchups were not loaded: %w", pWeek, ErrNotFound)
+   }
+
+   var weekData WeekData
+   weekData.mLeagueInfo = seasonData.mLeagueInfo
+   weekData.mYear = seasonData.mYear
+   weekData.mWeek = pWeek
+   weekData.mMatchups = matchups
+   weekData.mGameTimes = seasonData.mGameTimes[pWeek]
+   weekData.mIsRosterWeek = pWeek == seasonData.mRosterWeek
+
+   if pRequiredData & PrizeDataPlayers != 0 {
+      if seasonData.mPlayersErr != nil {
+         return WeekData{}, seasonData.mPlayersErr
+      }
+
+      if seasonData.mPlayers == nil {
+         return WeekData{}, fmt.Errorf("GetWeekData: Player data was not loaded: %w", ErrNotFound)
+      }
+
+      weekData.mPlayers = seasonData.mPlayers
+   }
+
+   if pRequiredData & PrizeDataPlayerStats != 0 {
+      playerStats, hasPlayerStats := seasonData.mPlayerStats[pWeek]
+
+      if !hasPlayerStats {
+         return WeekData{}, fmt.Errorf("GetWeekData: Week %d player stats were not loaded: %w", pWeek, ErrNotFound)
+      }
+
+      weekData.mPlayerStats = playerStats
+   }
+
+   if pRequiredData & PrizeDataProjections != 0 {
+      weekData.mProjections = seasonData.mProjections
+   }
+
+   if pRequiredData & PrizeDataBrackets != 0 {
+      winnersBracket, err := seasonData.GetBracket(WinnersBracket)
+
+      if err != nil {
+         return WeekData{}, err
+      }
+
+      weekData.mWinnersBracket = winnersBracket
+   }
+
+   return weekData, nil
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (seasonData SeasonData) GetBracket(pBracketType BracketType) (Bracket, error) {
+
+   if seasonData.mBracketsErr != nil {
+      return nil, seasonData.mBracketsErr
+   }
+
+   bracket, hasBracket := seasonData.mBrackets[pBracketType]
+
+   if !hasBracket {
+      return nil, fmt.Errorf("GetBracket: %s was not loaded: %w", pBracketType.Describe(), ErrNotFound)
+   }
+
+   return bracket, nil
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func makeUniqueWeeks(pWeeks []int) []int {
+   weekSet := make(map[int]bool)
+   var uniqueWeeks []int
+
+   for _, week := range pWeeks {
+      if !weekSet[week] {
+         weekSet[week] = true
+         uniqueWeeks = append(uniqueWeeks, week)
+      }
+   }
+
+   sort.Ints(uniqueWeeks)
+
+   return uniqueWeeks
+}
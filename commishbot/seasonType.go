@@ -0,0 +1,24 @@
+package main
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type SeasonType string
+
+const (
+   SeasonTypePre SeasonType = "pre"
+   SeasonTypeRegular SeasonType = "regular"
+   SeasonTypePost SeasonType = "post"
+)
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (seasonType SeasonType) IsValid() bool {
+   switch seasonType {
+   case SeasonTypePre, SeasonTypeRegular, SeasonTypePost:
+      return true
+   }
+
+   return false
+}
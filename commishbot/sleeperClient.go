@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const DefaultSleeperBaseUrl = "https://api.sleeper.app/v1"
+const DefaultSleeperProjectionsBaseUrl = "https://api.sleeper.com"
+
+// Sleeper documents a limit of roughly 1000 calls per minute, so stay comfortably below it.
+const defaultRequestsPerMinute = 600
+const defaultRequestBurst = 10
+const defaultRequestTimeout = 30 * time.Second
+
+const defaultCacheTtl = 15 * time.Minute
+const stateCacheTtl = time.Hour
+const playersCacheTtl = 24 * time.Hour
+const statsCacheTtl = time.Hour
+const projectionsCacheTtl = time.Hour
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type SleeperClient struct {
+   mBaseUrl string
+   mProjectionsBaseUrl string
+   mHttpClient *http.Client
+   mSnapshotDir string
+   mSnapshotMode CacheMode
+   mCache *HttpCache
+   mLimiter *RateLimiter
+   mRetryPolicy RetryPolicy
+   mRequestTimeout time.Duration
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func NewSleeperClient(pBaseUrl string, pProjectionsBaseUrl string, pHttpClient *http.Client) *SleeperClient {
+   var client SleeperClient
+
+   client.mBaseUrl = strings.TrimSuffix(pBaseUrl, "/")
+   client.mProjectionsBaseUrl = strings.TrimSuffix(pProjectionsBaseUrl, "/")
+   client.mHttpClient = pHttpClient
+
+   if client.mHttpClient == nil {
+      client.mHttpClient = http.DefaultClient
+   }
+
+   client.mLimiter = NewRateLimiter(defaultRequestsPerMinute, defaultRequestBurst)
+   client.mRetryPolicy = DefaultRetryPolicy
+   client.mRequestTimeout = defaultRequestTimeout
+
+   return &client
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func NewConfigSleeperClient(pConfig Config) *SleeperClient {
+   client := NewSleeperClient(pConfig.SleeperBaseUrl, pConfig.SleeperProjectionsBaseUrl, &http.Client{})
+   client.SetSnapshotDir(pConfig.StatsSnapshotDir, pConfig.CacheMode)
+
+   if pConfig.CacheDir != "" {
+      client.SetCache(NewHttpCache(pConfig.CacheDir, pConfig.CacheMode))
+   }
+
+   return client
+}
+
+//--------------------------------------------------------------------------------------------------
+// Snapshots follow the cache mode, so CacheModeRefresh refetches the stats and replaces them.
+//--------------------------------------------------------------------------------------------------
+func (client *SleeperClient) SetSnapshotDir(pSnapshotDir string, pMode CacheMode) {
+   client.mSnapshotDir = pSnapshotDir
+   client.mSnapshotMode = pMode
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (client *SleeperClient) SetCache(pCache *HttpCache) {
+   client.mCache = pCache
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (client *SleeperClient) SetRateLimiter(pLimiter *RateLimiter) {
+   client.mLimiter = pLimiter
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (client *SleeperClient) getHttpResponse(pContext context.Context, pUrl string, pTtl time.Duration) (string, error) {
+
+   if client.mCache == nil {
+      entry, err := client.fetchWithRetry(pContext, pUrl, HttpCacheEntry{})
+      return entry.Body, err
+   }
+
+   cachedEntry, isCached := client.mCache.Load(pUrl)
+
+   switch client.mCache.Mode() {
+   case CacheModeOffline:
+      if !isCached {
+         return "", &SleeperError{Kind: ErrNotCached, Resource: pUrl}
+      }
+
+      return cachedEntry.Body, nil
+
+   case CacheModeNormal:
+      if isCached && cachedEntry.IsFresh(pTtl, time.Now()) {
+         return cachedEntry.Body, nil
+      }
+   }
+
+   entry, err := client.fetchWithRetry(pContext, pUrl, cachedEntry)
+
+   if err != nil {
+      return "", err
+   }
+
+   entry.Permanent = pTtl == CacheForever
+   err = client.mCache.Store(entry)
+
+   if err != nil {
+      log.Printf("Failed to cache %s: %s", pUrl, err.Error())
+   }
+
+   return entry.Body, nil
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (client *SleeperClient) fetchWithRetry(pContext context.Context, pUrl string, pCachedEntry HttpCacheEntry) (HttpCacheEntry, error) {
+
+   maxAttempts := max(1, client.mRetryPolicy.MaxAttempts)
+
+   for attempt := 0 ; ; attempt++ {
+
+      if client.mLimiter != nil {
+         err := client.mLimiter.Wait(pContext)
+
+         if err != nil {
+            return HttpCacheEntry{}, &SleeperError{Kind: ErrRequest, Resource: pUrl, Err: err}
+         }
+      }
+
+      entry, err := client.fetch(pContext, pUrl, pCachedEntry)
+
+      if err == nil || attempt+1 >= maxAttempts || !isRetryableError(pContext, err) {
+         return entry, err
+      }
+
+      delay := client.mRetryPolicy.getDelay(attempt, err)
+      log.Printf("Retrying %s in %s: %s", pUrl, delay, err.Error())
+
+      err = sleepContext(pContext, delay)
+
+      if err != nil {
+         return HttpCacheEntry{}, &SleeperError{Kind: ErrRequest, Resource: pUrl, Err: err}
+      }
+   }
+}
+
+//--------------------------------------------------------------------------------------------------
+// A previously cached entry is revalidated with If-None-Match / If-Modified-Since, and a 304 reply
+// returns the cached body with a refreshed fetch time.
+//--------------------------------------------------------------------------------------------------
+func (client *SleeperClient) fetch(pContext context.Context, pUrl string, pCachedEntry HttpCacheEntry) (HttpCacheEntry, error) {
+
+   requestContext, cancel := context.WithTimeout(pContext, client.mRequestTimeout)
+   defer cancel()
+
+   request, err := http.NewRequestWithContext(requestContext, http.MethodGet, pUrl, nil)
+
+   if err != nil {
+      return HttpCacheEntry{}, &SleeperError{Kind: ErrRequest, Resource: pUrl, Err: err}
+   }
+
+   if pCachedEntry.ETag != "" {
+      request.Header.Set("If-None-Match", pCachedEntry.ETag)
+   }
+
+   if pCachedEntry.LastModified != "" {
+      request.Header.Set("If-Modified-Since", pCachedEntry.LastModified)
+   }
+
+   resp, err := client.mHttpClient.Do(request)
+
+   if err != nil {
+      return HttpCacheEntry{}, &SleeperError{Kind: ErrRequest, Resource: pUrl, Err: err}
+   }
+
+   defer resp.Body.Close()
+
+   if resp.StatusCode == http.StatusNotModified && pCachedEntry.Url == pUrl {
+      pCachedEntry.FetchedAt = time.Now()
+      return pCachedEntry, nil
+   }
+
+   if resp.StatusCode != http.StatusOK {
+      return HttpCacheEntry{}, makeStatusError(pUrl, resp)
+   }
+
+   body, err := io.ReadAll(resp.Body)
+
+   if err != nil {
+      return HttpCacheEntry{}, &SleeperError{Kind: ErrRequest, Resource: pUrl, Err: err}
+   }
+
+   var entry HttpCacheEntry
+   entry.Url = pUrl
+   entry.FetchedAt = time.Now()
+   entry.ETag = resp.Header.Get("ETag")
+   entry.LastModified = resp.Header.Get("Last-Modified")
+   entry.Body = string(body)
+
+   return entry, nil
+}
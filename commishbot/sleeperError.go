@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrRequest = errors.New("request failed")
+var ErrNotFound = errors.New("not found")
+var ErrRateLimited = errors.New("rate limited")
+var ErrHttpStatus = errors.New("unexpected http status")
+var ErrDecode = errors.New("decode failed")
+var ErrNotCached = errors.New("response is not cached and offline mode is enabled")
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type SleeperError struct {
+   Kind error
+   Resource string
+   StatusCode int
+   RetryAfter time.Duration
+   Err error
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (sleeperError *SleeperError) Error() string {
+   var message strings.Builder
+
+   message.WriteString("Sleeper " + sleeperError.Kind.Error())
+
+   if sleeperError.Resource != "" {
+      message.WriteString(" (" + sleeperError.Resource + ")")
+   }
+
+   if sleeperError.StatusCode != 0 {
+      message.WriteString(" (Status: " + strconv.Itoa(sleeperError.StatusCode) + ")")
+   }
+
+   if sleeperError.Err != nil {
+      message.WriteString(": " + sleeperError.Err.Error())
+   }
+
+   return message.String()
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (sleeperError *SleeperError) Unwrap() []error {
+   errs := []error{sleeperError.Kind}
+
+   if sleeperError.Err != nil {
+      errs = append(errs, sleeperError.Err)
+   }
+
+   return errs
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func makeStatusError(pUrl string, pResponse *http.Response) error {
+
+   kind := ErrHttpStatus
+
+   switch pResponse.StatusCode {
+   case http.StatusNotFound:
+      kind = ErrNotFound
+   case http.StatusTooManyRequests:
+      kind = ErrRateLimited
+   }
+
+   sleeperError := &SleeperError{Kind: kind, Resource: pUrl, StatusCode: pResponse.StatusCode}
+
+   if retryAfterSeconds, err := strconv.Atoi(pResponse.Header.Get("Retry-After")) ; err == nil {
+      sleeperError.RetryAfter = time.Duration(retryAfterSeconds) * time.Second
+   }
+
+   return sleeperError
+}
+
+//--------------------------------------------------------------------------------------------------
+// Sleeper answers lookups of unknown users and leagues with a literal "null" body rather than a 404,
+// so that case is reported as ErrNotFound.
+//--------------------------------------------------------------------------------------------------
+func unmarshalSleeperData(pData string, pDescription string, pValue any) error {
+
+   if strings.TrimSpace(pData) == "null" {
+      return &SleeperError{Kind: ErrNotFound, Resource: pDescription}
+   }
+
+   err := json.Unmarshal([]byte(pData), pValue)
+
+   if err != nil {
+      return &SleeperError{Kind: ErrDecode, Resource: pDescription, Err: err}
+   }
+
+   return nil
+}
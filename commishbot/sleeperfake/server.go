@@ -0,0 +1,138 @@
+package sleeperfake
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const projectionsPrefix = "/projections/nfl/player/"
+
+//--------------------------------------------------------------------------------------------------
+// Server serves Sleeper API responses from a fixtures directory. A request for /v1/league/123 is
+// answered with <fixtures>/v1/league/123.json, and unknown paths get a 404. Player projections are
+// read from <fixtures>/projections/<season>.json, which maps player Ids to the per-week projections
+// the real endpoint returns for a single player.
+//--------------------------------------------------------------------------------------------------
+type Server struct {
+   *httptest.Server
+
+   mFixturesDir string
+
+   mMutex sync.Mutex
+   mRequestCounts map[string]int
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func NewServer(pFixturesDir string) *Server {
+   var server Server
+
+   server.mFixturesDir = pFixturesDir
+   server.mRequestCounts = make(map[string]int)
+   server.Server = httptest.NewServer(http.HandlerFunc(server.serveHttp))
+
+   return &server
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (server *Server) BaseUrl() string {
+   return server.URL + "/v1"
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (server *Server) ProjectionsBaseUrl() string {
+   return server.URL
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (server *Server) RequestCount(pPath string) int {
+   server.mMutex.Lock()
+   defer server.mMutex.Unlock()
+
+   return server.mRequestCounts[pPath]
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (server *Server) TotalRequestCount() int {
+   server.mMutex.Lock()
+   defer server.mMutex.Unlock()
+
+   totalRequestCount := 0
+
+   for _, requestCount := range server.mRequestCounts {
+      totalRequestCount += requestCount
+   }
+
+   return totalRequestCount
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (server *Server) serveHttp(pWriter http.ResponseWriter, pRequest *http.Request) {
+
+   requestPath := path.Clean(pRequest.URL.Path)
+
+   server.mMutex.Lock()
+   server.mRequestCounts[requestPath]++
+   server.mMutex.Unlock()
+
+   var body []byte
+   var err error
+
+   if strings.HasPrefix(requestPath, projectionsPrefix) {
+      body, err = server.readProjections(strings.TrimPrefix(requestPath, projectionsPrefix), pRequest.URL.Query().Get("season"))
+   } else {
+      body, err = os.ReadFile(filepath.Join(server.mFixturesDir, filepath.FromSlash(requestPath) + ".json"))
+   }
+
+   if err != nil {
+      http.NotFound(pWriter, pRequest)
+      return
+   }
+
+   pWriter.Header().Set("Content-Type", "application/json")
+   pWriter.Write(body)
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (server *Server) readProjections(pPlayerId string, pSeason string) ([]byte, error) {
+
+   projectionsBytes, err := os.ReadFile(filepath.Join(server.mFixturesDir, "projections", pSeason + ".json"))
+
+   if err != nil {
+      return nil, err
+   }
+
+   var projections map[string]json.RawMessage
+   err = json.Unmarshal(projectionsBytes, &projections)
+
+   if err != nil {
+      return nil, err
+   }
+
+   playerProjections, hasPlayer := projections[pPlayerId]
+
+   if !hasPlayer {
+      return nil, os.ErrNotExist
+   }
+
+   return playerProjections, nil
+}
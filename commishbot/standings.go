@@ -0,0 +1,325 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"strconv"
+)
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type Record struct {
+   Wins int
+   Losses int
+   Ties int
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type TeamStanding struct {
+   Rank int
+   Roster_id int
+   Owner string
+   Division int
+   DivisionName string
+   Record Record
+   DivisionRecord Record
+   MedianRecord Record
+   AllPlayRecord Record
+   PointsFor float64
+   PointsAgainst float64
+   Streak string
+
+   mResults []MatchupResult
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type Standings struct {
+   ThroughWeek int
+   HasDivisions bool
+   HasMedian bool
+   Teams []TeamStanding
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type MatchupResult int
+
+const (
+   MatchupWin MatchupResult = iota
+   MatchupLoss
+   MatchupTie
+)
+
+//--------------------------------------------------------------------------------------------------
+// GetStandings tallies every regular season game from week 1 through pThroughWeek. Points come from
+// Matchup.Points, which includes any commissioner adjustments. Teams without an opponent still score
+// points for the week, the all-play record and the median game, but play no head to head game. In
+// median leagues the median result counts toward the overall record. Teams are ranked by win
+// percentage and then points for.
+//--------------------------------------------------------------------------------------------------
+func GetStandings(pSeasonData SeasonData, pThroughWeek int) (Standings, error) {
+
+   league := pSeasonData.mLeagueInfo.mLeague
+
+   var standings Standings
+   standings.ThroughWeek = pThroughWeek
+   standings.HasDivisions = league.Settings.Divisions > 1
+   standings.HasMedian = league.HasMedianMatchup()
+
+   teamIdxs := make(map[int]int)
+
+   for _, roster := range pSeasonData.mLeagueInfo.mRosters {
+      teamIdxs[roster.Roster_id] = len(standings.Teams)
+
+      var teamStanding TeamStanding
+      teamStanding.Roster_id = roster.Roster_id
+      teamStanding.Owner = pSeasonData.mLeagueInfo.mDisplayNames[roster.Owner_id]
+      teamStanding.Division = roster.Settings.Division
+      teamStanding.DivisionName = league.GetDivisionName(roster.Settings.Division)
+
+      standings.Teams = append(standings.Teams, teamStanding)
+   }
+
+   for week := 1 ; week <= pThroughWeek ; week++ {
+
+      weekData, err := pSeasonData.GetWeekData(week, 0)
+
+      if err != nil {
+         return Standings{}, fmt.Errorf("GetStandings: Week %d: %w", week, err)
+      }
+
+      weekMatchups := getPlayingMatchups(weekData.mMatchups)
+      medianPoints := getMedianPoints(weekMatchups)
+
+      for _, matchup := range weekMatchups {
+
+         teamIdx, hasTeam := teamIdxs[matchup.Roster_id]
+
+         if !hasTeam {
+            return Standings{}, fmt.Errorf("GetStandings: Week %d roster (Id: %d) is not in the league: %w", week, matchup.Roster_id, ErrNotFound)
+         }
+
+         teamStanding := &standings.Teams[teamIdx]
+         teamStanding.PointsFor += matchup.Points
+
+         for _, otherMatchup := range weekMatchups {
+            if otherMatchup.Roster_id != matchup.Roster_id {
+               teamStanding.AllPlayRecord.Add(compareMatchupPoints(matchup.Points, otherMatchup.Points))
+            }
+         }
+
+         if standings.HasMedian {
+            medianResult := compareMatchupPoints(matchup.Points, medianPoints)
+            teamStanding.MedianRecord.Add(medianResult)
+            teamStanding.Record.Add(medianResult)
+         }
+
+         opponentMatchup, err := GetMatchupOpponentRoster(weekData.mMatchups, matchup.Roster_id)
+
+         if errors.Is(err, ErrNoOpponent) {
+            continue
+         }
+
+         if err != nil {
+            return Standings{}, fmt.Errorf("GetStandings: Week %d: %w", week, err)
+         }
+
+         result := compareMatchupPoints(matchup.Points, opponentMatchup.Points)
+         teamStanding.Record.Add(result)
+         teamStanding.PointsAgainst += opponentMatchup.Points
+         teamStanding.mResults = append(teamStanding.mResults, result)
+
+         opponentIdx, hasOpponent := teamIdxs[opponentMatchup.Roster_id]
+
+         if standings.HasDivisions && hasOpponent && teamStanding.Division == standings.Teams[opponentIdx].Division {
+            teamStanding.DivisionRecord.Add(result)
+         }
+      }
+   }
+
+   for idx := range standings.Teams {
+      standings.Teams[idx].Streak = formatStreak(standings.Teams[idx].mResults)
+   }
+
+   sort.SliceStable(standings.Teams, func(i, j int) bool {
+      lhs, rhs := standings.Teams[i], standings.Teams[j]
+
+      if lhs.Record.GetWinPercentage() != rhs.Record.GetWinPercentage() {
+         return lhs.Record.GetWinPercentage() > rhs.Record.GetWinPercentage()
+      }
+
+      return lhs.PointsFor > rhs.PointsFor
+   })
+
+   for idx := range standings.Teams {
+      standings.Teams[idx].Rank = idx+1
+   }
+
+   return standings, nil
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (record *Record) Add(pResult MatchupResult) {
+   switch pResult {
+   case MatchupWin:
+      record.Wins++
+   case MatchupLoss:
+      record.Losses++
+   case MatchupTie:
+      record.Ties++
+   }
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (record Record) GetNumGames() int {
+   return record.Wins + record.Losses + record.Ties
+}
+
+//--------------------------------------------------------------------------------------------------
+// Ties count as half a win.
+//--------------------------------------------------------------------------------------------------
+func (record Record) GetWinPercentage() float64 {
+
+   if record.GetNumGames() == 0 {
+      return 0.0
+   }
+
+   return (float64(record.Wins) + 0.5 * float64(record.Ties)) / float64(record.GetNumGames())
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (record Record) String() string {
+   return fmt.Sprintf("%d-%d-%d", record.Wins, record.Losses, record.Ties)
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (standings Standings) Print() {
+
+   log.Printf("Standings Through Week %d", standings.ThroughWeek)
+
+   header := fmt.Sprintf("   %-4s %-16s %-8s %8s %8s %-6s %-8s", "Rank", "Owner", "Record", "PF", "PA", "Strk", "All-Play")
+
+   if standings.HasMedian {
+      header += fmt.Sprintf(" %-8s", "Median")
+   }
+
+   if standings.HasDivisions {
+      header += fmt.Sprintf(" %-8s %s", "Div", "Division")
+   }
+
+   log.Print(header)
+
+   for _, team := range standings.Teams {
+
+      line := fmt.Sprintf("   %-4d %-16s %-8s %8.2f %8.2f %-6s %-8s", team.Rank, team.Owner, team.Record, team.PointsFor, team.PointsAgainst, team.Streak, team.AllPlayRecord)
+
+      if standings.HasMedian {
+         line += fmt.Sprintf(" %-8s", team.MedianRecord)
+      }
+
+      if standings.HasDivisions {
+         line += fmt.Sprintf(" %-8s %s", team.DivisionRecord, team.DivisionName)
+      }
+
+      log.Print(line)
+   }
+}
+
+//--------------------------------------------------------------------------------------------------
+// Teams on a bye have no matchup id and sit out the all-play and median games.
+//--------------------------------------------------------------------------------------------------
+func getPlayingMatchups(pMatchups []Matchup) []Matchup {
+   var playingMatchups []Matchup
+
+   for _, matchup := range pMatchups {
+      if matchup.Matchup_id != 0 {
+         playingMatchups = append(playingMatchups, matchup)
+      }
+   }
+
+   return playingMatchups
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func getMedianPoints(pMatchups []Matchup) float64 {
+   var points []float64
+
+   for _, matchup := range pMatchups {
+      points = append(points, matchup.Points)
+   }
+
+   return getMedian(points)
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func getMedian(pValues []float64) float64 {
+
+   if len(pValues) == 0 {
+      return 0.0
+   }
+
+   values := slices.Clone(pValues)
+   slices.Sort(values)
+   middleIdx := len(values) / 2
+
+   if len(values) % 2 == 0 {
+      return (values[middleIdx-1] + values[middleIdx]) / 2.0
+   }
+
+   return values[middleIdx]
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func compareMatchupPoints(pPoints float64, pOpponentPoints float64) MatchupResult {
+
+   switch compareScores(pPoints, pOpponentPoints, SortDescending) {
+   case -1:
+      return MatchupWin
+   case 1:
+      return MatchupLoss
+   }
+
+   return MatchupTie
+}
+
+//--------------------------------------------------------------------------------------------------
+// formatStreak describes the run of identical results at the end of pResults, such as "W3".
+//--------------------------------------------------------------------------------------------------
+func formatStreak(pResults []MatchupResult) string {
+
+   if len(pResults) == 0 {
+      return "-"
+   }
+
+   lastResult := pResults[len(pResults)-1]
+   streakLength := 0
+
+   for idx := len(pResults)-1 ; idx >= 0 && pResults[idx] == lastResult ; idx-- {
+      streakLength++
+   }
+
+   return []string{"W", "L", "T"}[lastResult] + strconv.Itoa(streakLength)
+}
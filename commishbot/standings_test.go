@@ -0,0 +1,65 @@
+package main
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func TestStandings(pTest *testing.T) {
+
+   _, seasonData := newTestSeason(pTest, makeDefaultTestSchedule(pTest))
+
+   expectedTeams := []struct {
+      owner string
+      record string
+      allPlay string
+      divisionRecord string
+      streak string
+      pointsFor float64
+      pointsAgainst float64
+   }{
+      {"Jaguars", "9-3-0", "75-42-0", "3-2-0", "L1", 1743.66, 1349.50},
+      {"Icemen", "8-5-0", "63-53-1", "4-2-0", "W1", 1547.88, 1620.27},
+      {"Giants", "8-5-1", "75-48-1", "4-3-0", "W1", 1774.31, 1649.70},
+      {"Aces", "7-5-2", "67-55-2", "5-2-1", "W4", 1715.08, 1694.69},
+      {"Hornets", "7-7-0", "68-56-0", "4-2-0", "L1", 1734.45, 1750.24},
+      {"Dragons", "6-8-0", "59-65-0", "2-4-0", "L2", 1665.61, 1639.82},
+      {"Eagles", "6-8-0", "55-69-0", "3-3-0", "L1", 1647.75, 1685.79},
+      {"Cobras", "6-8-0", "40-84-0", "4-2-0", "L5", 1509.33, 1574.46},
+      {"Bandits", "5-7-1", "64-58-2", "1-4-1", "W1", 1699.35, 1655.47},
+      {"Falcons", "4-10-0", "44-80-0", "0-6-0", "W2", 1512.53, 1685.31},
+   }
+
+   standings, err := GetStandings(seasonData, 14)
+
+   if err != nil {
+      pTest.Fatalf("GetStandings: %s", err.Error())
+   }
+
+   if len(standings.Teams) != len(expectedTeams) {
+      pTest.Fatalf("%d teams, expected %d", len(standings.Teams), len(expectedTeams))
+   }
+
+   for idx, expectedTeam := range expectedTeams {
+
+      team := standings.Teams[idx]
+      actual := []string{team.Owner, team.Record.String(), team.AllPlayRecord.String(), team.DivisionRecord.String(), team.Streak}
+      expected := []string{expectedTeam.owner, expectedTeam.record, expectedTeam.allPlay, expectedTeam.divisionRecord, expectedTeam.streak}
+
+      if !slices.Equal(actual, expected) || math.Abs(team.PointsFor - expectedTeam.pointsFor) > 1e-6 || math.Abs(team.PointsAgainst - expectedTeam.pointsAgainst) > 1e-6 {
+         pTest.Errorf("Rank %d: %v %.2f %.2f, expected %v %.2f %.2f", idx+1, actual, team.PointsFor, team.PointsAgainst, expected, expectedTeam.pointsFor, expectedTeam.pointsAgainst)
+      }
+   }
+
+   // In a median league the Jaguars also beat the median in 10 of the 13 weeks they played
+   seasonData.mLeagueInfo.mLeague.Settings.League_average_match = 1
+   standings, _ = GetStandings(seasonData, 14)
+
+   if team := standings.Teams[0] ; team.Owner != "Jaguars" || team.MedianRecord.String() != "10-3-0" || team.Record.String() != "19-6-0" {
+      pTest.Errorf("Median league leader %s %s (Median %s), expected Jaguars 19-6-0 (Median 10-3-0)", team.Owner, team.Record, team.MedianRecord)
+   }
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"sync"
+)
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type TaskGroup struct {
+   mContext context.Context
+   mCancel context.CancelFunc
+   mSemaphore chan struct{}
+   mWaitGroup sync.WaitGroup
+   mErrOnce sync.Once
+   mErr error
+}
+
+//--------------------------------------------------------------------------------------------------
+// The returned context is cancelled as soon as any task fails, so the remaining tasks can stop
+// early, and at most pParallelism tasks run at a time.
+//--------------------------------------------------------------------------------------------------
+func NewTaskGroup(pContext context.Context, pParallelism int) (*TaskGroup, context.Context) {
+   var group TaskGroup
+
+   group.mContext, group.mCancel = context.WithCancel(pContext)
+   group.mSemaphore = make(chan struct{}, max(1, pParallelism))
+
+   return &group, group.mContext
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (group *TaskGroup) Go(pTask func(pContext context.Context) error) {
+
+   group.mWaitGroup.Add(1)
+
+   go func() {
+      defer group.mWaitGroup.Done()
+
+      select {
+      case group.mSemaphore <- struct{}{}:
+         defer func() { <-group.mSemaphore }()
+      case <-group.mContext.Done():
+         group.fail(group.mContext.Err())
+         return
+      }
+
+      err := pTask(group.mContext)
+
+      if err != nil {
+         group.fail(err)
+      }
+   }()
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (group *TaskGroup) Wait() error {
+   group.mWaitGroup.Wait()
+   group.mCancel()
+
+   return group.mErr
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (group *TaskGroup) fail(pErr error) {
+   group.mErrOnce.Do(func() {
+      group.mErr = pErr
+      group.mCancel()
+   })
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type TieBreaker struct {
+   Name string
+   SortOrder SortOrder
+   Value func(pWeekData WeekData, pMatchup Matchup, pRoster Roster) float64
+}
+
+var TieBreakerStarterPoints = TieBreaker{"Total Starter Points", SortDescending, getStarterPointsTieBreakerValue}
+var TieBreakerBenchPoints = TieBreaker{"Bench Points", SortDescending, getBenchPointsTieBreakerValue}
+var TieBreakerFewestMoves = TieBreaker{"Fewest Roster Moves", SortAscending, getRosterMovesTieBreakerValue}
+
+var defaultTieBreakers = []TieBreaker{TieBreakerStarterPoints, TieBreakerBenchPoints, TieBreakerFewestMoves}
+
+var tieBreakerRegistry = map[string]TieBreaker{
+   makePrizeKey(TieBreakerStarterPoints.Name): TieBreakerStarterPoints,
+   makePrizeKey(TieBreakerBenchPoints.Name): TieBreakerBenchPoints,
+   makePrizeKey(TieBreakerFewestMoves.Name): TieBreakerFewestMoves,
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type tieBreakerPrize struct {
+   Prize
+   mTieBreakers []TieBreaker
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func GetTieBreaker(pName string) (TieBreaker, bool) {
+   tieBreaker, hasKey := tieBreakerRegistry[makePrizeKey(pName)]
+   return tieBreaker, hasKey
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func getTieBreakers(pNames []string) ([]TieBreaker, error) {
+   var tieBreakers []TieBreaker
+   var errs []error
+
+   for _, name := range pNames {
+      tieBreaker, hasTieBreaker := GetTieBreaker(name)
+
+      if !hasTieBreaker {
+         errs = append(errs, fmt.Errorf("unknown tie-breaker %q", name))
+         continue
+      }
+
+      tieBreakers = append(tieBreakers, tieBreaker)
+   }
+
+   return tieBreakers, errors.Join(errs...)
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func getTieBreakerNames(pTieBreakers []TieBreaker) []string {
+   var names []string
+
+   for _, tieBreaker := range pTieBreakers {
+      names = append(names, tieBreaker.Name)
+   }
+
+   return names
+}
+
+//--------------------------------------------------------------------------------------------------
+// WithTieBreakers returns a copy of the prize that breaks ties with pTieBreakers instead of the
+// prize's own list.
+//--------------------------------------------------------------------------------------------------
+func WithTieBreakers(pPrize Prize, pTieBreakers []TieBreaker) Prize {
+   return tieBreakerPrize{pPrize, pTieBreakers}
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (prize tieBreakerPrize) TieBreakers() []TieBreaker {
+   return prize.mTieBreakers
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func getStarterPointsTieBreakerValue(pWeekData WeekData, pMatchup Matchup, pRoster Roster) float64 {
+   return pMatchup.GetTotalStarterPoints()
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func getBenchPointsTieBreakerValue(pWeekData WeekData, pMatchup Matchup, pRoster Roster) float64 {
+   benchPoints := 0.0
+
+   for _, benchPlayerPoints := range pMatchup.GetBenchPlayerPoints() {
+      benchPoints += benchPlayerPoints
+   }
+
+   return benchPoints
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func getRosterMovesTieBreakerValue(pWeekData WeekData, pMatchup Matchup, pRoster Roster) float64 {
+   return float64(pRoster.Settings.Total_moves)
+}
@@ -1,6 +1,6 @@
 package main
 
-import "encoding/json"
+import "context"
 
 //--------------------------------------------------------------------------------------------------
 //
@@ -13,19 +13,26 @@ type User struct {
 //--------------------------------------------------------------------------------------------------
 //
 //--------------------------------------------------------------------------------------------------
-func GetUserData(pUsername string) string {
-   return GetHttpResponse("https://api.sleeper.app/v1/user/" + pUsername)
+func (client *SleeperClient) GetUserData(pContext context.Context, pUsername string) (string, error) {
+   return client.getHttpResponse(pContext, client.mBaseUrl + "/user/" + pUsername, defaultCacheTtl)
 }
 
 //--------------------------------------------------------------------------------------------------
 //
 //--------------------------------------------------------------------------------------------------
-func GetUser(pUsername string) User {
-   userData := GetUserData(pUsername)
+func (client *SleeperClient) GetUser(pContext context.Context, pUsername string) (User, error) {
+   userData, err := client.GetUserData(pContext, pUsername)
+
+   if err != nil {
+      return User{}, err
+   }
 
    var user User
-   err := json.Unmarshal([]byte(userData), &user)
-   check(err)
+   err = unmarshalSleeperData(userData, "user " + pUsername, &user)
+
+   if err != nil {
+      return User{}, err
+   }
 
-   return user
+   return user, nil
 }
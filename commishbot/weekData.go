@@ -0,0 +1,17 @@
+package main
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+type WeekData struct {
+   mLeagueInfo LeagueInfo
+   mYear int
+   mWeek int
+   mMatchups []Matchup
+   mPlayers map[string]Player
+   mPlayerStats map[string]PlayerStats
+   mProjections *ProjectionStore
+   mGameTimes WeekGameTimes
+   mIsRosterWeek bool
+   mWinnersBracket Bracket
+}
@@ -1,6 +1,10 @@
 package main
 
-import "log"
+import (
+	"log"
+	"strconv"
+	"strings"
+)
 
 //--------------------------------------------------------------------------------------------------
 //
@@ -9,7 +13,79 @@ type WeekSummary struct {
    Week int
    Criteria string
    PrizeEntries PrizeEntries
-   Err error
+   IneligibleEntries PrizeEntries
+   DecidingTieBreaker string
+   Rollover RolloverPolicy
+   RolledOverWeeks []int
+   Finalized bool
+   Error string `json:",omitempty"`
+   Err error `json:"-"`
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func GetWeekSummary(pPrize Prize, pSeasonData SeasonData, pWeek int) WeekSummary {
+
+   var summary WeekSummary
+   summary.Week = pWeek
+   summary.Criteria = pPrize.Name() + " - " + pPrize.Criteria()
+
+   tieBreakers := pPrize.TieBreakers()
+   weekData, err := pSeasonData.GetWeekData(pWeek, pPrize.RequiredData())
+
+   if err != nil {
+      return summary.withErr(err)
+   }
+
+   for _, roster := range weekData.mLeagueInfo.mRosters {
+
+      matchupRoster, err := GetMatchupRoster(weekData.mMatchups, roster.Roster_id)
+
+      if err != nil {
+         return summary.withErr(err)
+      }
+
+      prizeEntry, err := pPrize.Score(weekData, matchupRoster)
+
+      if err != nil {
+         return summary.withErr(err)
+      }
+
+      prizeEntry.Owner = weekData.mLeagueInfo.mDisplayNames[roster.Owner_id]
+      prizeEntry.Roster_id = roster.Roster_id
+
+      for _, tieBreaker := range tieBreakers {
+         prizeEntry.TieBreakerValues = append(prizeEntry.TieBreakerValues, tieBreaker.Value(weekData, matchupRoster, roster))
+      }
+
+      if prizeEntry.Eligibility == Ineligible {
+         summary.IneligibleEntries = append(summary.IneligibleEntries, prizeEntry)
+      } else {
+         summary.PrizeEntries = append(summary.PrizeEntries, prizeEntry)
+      }
+   }
+
+   summary.DecidingTieBreaker = summary.PrizeEntries.Rank(pPrize.SortOrder(), tieBreakers)
+
+   return summary
+}
+
+//--------------------------------------------------------------------------------------------------
+// Error repeats Err for JSON output, where a failed week must not look like one without a winner.
+//--------------------------------------------------------------------------------------------------
+func (summary WeekSummary) withErr(pErr error) WeekSummary {
+   summary.Err = pErr
+   summary.Error = pErr.Error()
+
+   return summary
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func (summary WeekSummary) HasWinner() bool {
+   return summary.Err == nil && len(summary.PrizeEntries) > 0
 }
 
 //--------------------------------------------------------------------------------------------------
@@ -24,7 +100,60 @@ func (summary WeekSummary) Print() {
 
    log.Printf("Week %d Criteria: %s", summary.Week, summary.Criteria)
 
-   for idx, prizeEntry := range summary.PrizeEntries {
-      log.Printf("   %d. Owner: %s, Score: %f", idx+1, prizeEntry.Owner, prizeEntry.Score)
+   if !summary.HasWinner() {
+      log.Printf("   No winner this week, %s", summary.Rollover.Describe())
+   }
+
+   if len(summary.RolledOverWeeks) > 0 {
+      log.Printf("   Includes the prizes rolled over from week(s) %s", formatWeeks(summary.RolledOverWeeks))
+   }
+
+   winners := summary.PrizeEntries.Winners()
+
+   if len(winners) > 1 {
+      log.Printf("   Tied for first: %s", strings.Join(getOwners(winners), ", "))
+   } else if summary.DecidingTieBreaker != "" {
+      log.Printf("   Winner decided by tie-breaker: %s", summary.DecidingTieBreaker)
+   }
+
+   for _, prizeEntry := range summary.PrizeEntries {
+      rank := strconv.Itoa(prizeEntry.Rank)
+
+      if summary.PrizeEntries.isTied(prizeEntry.Rank) {
+         rank = "T" + rank
+      }
+
+      log.Printf("   %s. Owner: %s, Score: %f", rank, prizeEntry.Owner, prizeEntry.Score)
+      printEvidence(prizeEntry.Evidence, "      ")
+   }
+   if len(summary.IneligibleEntries) > 0 {
+      log.Printf("   Ineligible:")
+   }
+
+   for _, prizeEntry := range summary.IneligibleEntries {
+      log.Printf("      Owner: %s, Reason: %s", prizeEntry.Owner, prizeEntry.Reason)
+      printEvidence(prizeEntry.Evidence, "         ")
    }
 }
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func printEvidence(pEvidence PrizeEvidence, pIndent string) {
+   for _, line := range pEvidence.Describe() {
+      log.Printf("%s%s", pIndent, line)
+   }
+}
+
+//--------------------------------------------------------------------------------------------------
+//
+//--------------------------------------------------------------------------------------------------
+func formatWeeks(pWeeks []int) string {
+   var weeks []string
+
+   for _, week := range pWeeks {
+      weeks = append(weeks, strconv.Itoa(week))
+   }
+
+   return strings.Join(weeks, ", ")
+}